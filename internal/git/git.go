@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/elsejj/verit/pkg/projectid"
 )
@@ -135,3 +136,31 @@ func Run(dir string, args ...string) error {
 	}
 	return nil
 }
+
+// Output executes a git command within dir and returns its trimmed standard output.
+func Output(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", fmt.Errorf("git %s failed: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Head returns the hash and the commit time of HEAD.
+func Head(dir string) (string, time.Time, error) {
+	out, err := Output(dir, "show", "-s", "--format=%H %cI", "HEAD")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	commit, date, _ := strings.Cut(out, " ")
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid commit time %q: %w", date, err)
+	}
+	return commit, t, nil
+}
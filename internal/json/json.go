@@ -0,0 +1,274 @@
+// Package json is a small, format preserving JSON reader/writer.
+//
+// Unlike encoding/json it keeps the position of every value in the original
+// bytes, so a single value can be replaced while indentation, key order and the
+// trailing newline of the document stay exactly as they were.
+//
+// JSONC documents, JSON with comments and trailing commas, are read by LoadJSONC
+// and ParseJSONC, their comments are kept as well.
+package json
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Kind is the type of a JSON value.
+type Kind int
+
+const (
+	String Kind = iota
+	Number
+	Bool
+	Null
+	Array
+	Object
+)
+
+// Value is a parsed JSON value with its location in the document.
+type Value struct {
+	Kind Kind
+	// Text is the decoded content of strings, or the raw text of other scalars
+	Text string
+	// Members holds the key/value pairs of an object in document order
+	Members []*Member
+	// Items holds the elements of an array
+	Items []*Value
+	// Start and End are the byte offsets of the raw value in the document
+	Start int
+	End   int
+}
+
+// Member is a key/value pair of an object.
+type Member struct {
+	Key   string
+	Value *Value
+	// keyStart and keyEnd are the byte offsets of the quoted key
+	keyStart int
+	keyEnd   int
+}
+
+// Get returns the value of the first member named key, or nil.
+func (v *Value) Get(key string) *Value {
+	if v == nil || v.Kind != Object {
+		return nil
+	}
+	for _, m := range v.Members {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+	return nil
+}
+
+// Document is a parsed JSON file.
+type Document struct {
+	data  []byte
+	root  *Value
+	jsonc bool
+}
+
+// Load reads and parses fileName.
+func Load(fileName string) (*Document, error) {
+	return load(fileName, false)
+}
+
+// LoadJSONC reads and parses fileName as JSONC.
+func LoadJSONC(fileName string) (*Document, error) {
+	return load(fileName, true)
+}
+
+func load(fileName string, jsonc bool) (*Document, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parse(data, jsonc)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", fileName, err)
+	}
+	return doc, nil
+}
+
+// Parse parses data as a JSON document.
+func Parse(data []byte) (*Document, error) {
+	return parse(data, false)
+}
+
+// ParseJSONC parses data as a JSONC document.
+func ParseJSONC(data []byte) (*Document, error) {
+	return parse(data, true)
+}
+
+func parse(data []byte, jsonc bool) (*Document, error) {
+	p := &parser{data: data, jsonc: jsonc}
+	if bytes.HasPrefix(data, utf8BOM) {
+		p.pos = len(utf8BOM)
+	}
+	p.skipBlank()
+	root, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q after top-level value", p.peek())
+	}
+	return &Document{data: data, root: root, jsonc: jsonc}, nil
+}
+
+// Bytes returns the current content of the document.
+func (d *Document) Bytes() []byte {
+	return d.data
+}
+
+// Root returns the top-level value.
+func (d *Document) Root() *Value {
+	return d.root
+}
+
+// Save writes the document to fileName, keeping the mode of an existing file.
+func (d *Document) Save(fileName string) error {
+	var mode os.FileMode = 0o644
+	if stat, err := os.Stat(fileName); err == nil {
+		mode = stat.Mode()
+	}
+	return os.WriteFile(fileName, d.data, mode)
+}
+
+// Get walks the object keys in path from the root, e.g. Get("version") only
+// returns the top-level version, never a nested one. It returns nil if not found.
+func (d *Document) Get(path ...string) *Value {
+	v := d.root
+	for _, key := range path {
+		v = v.Get(key)
+		if v == nil {
+			return nil
+		}
+	}
+	return v
+}
+
+// GetString returns the string at path.
+func (d *Document) GetString(path ...string) (string, bool) {
+	v := d.Get(path...)
+	if v == nil || v.Kind != String {
+		return "", false
+	}
+	return v.Text, true
+}
+
+// SetString replaces the string at path.
+func (d *Document) SetString(value string, path ...string) error {
+	v := d.Get(path...)
+	if v == nil {
+		return fmt.Errorf("key %s not found", strings.Join(path, "."))
+	}
+	if v.Kind != String {
+		return fmt.Errorf("key %s is not a string", strings.Join(path, "."))
+	}
+	return d.Replace(v, Quote(value))
+}
+
+// InsertString adds a string member to the top-level object, right after the
+// member named after, or first when there is no such member. The separators
+// around the new member mimic the existing ones, comments ending the line of a
+// member stay with it.
+func (d *Document) InsertString(key, value, after string) error {
+	root := d.root
+	if root.Kind != Object {
+		return fmt.Errorf("top-level value is not an object")
+	}
+	if root.Get(key) != nil {
+		return fmt.Errorf("key %s already exists", key)
+	}
+	if len(root.Members) == 0 {
+		return d.Replace(root, "{"+Quote(key)+": "+Quote(value)+"}")
+	}
+
+	first := root.Members[0]
+	colon := string(d.data[first.keyEnd:first.Value.Start])
+	// the comma and whitespace between members, e.g. ",\n  " or ","
+	sep := memberSeparator(d.data[root.Start+1 : first.keyStart])
+	if len(root.Members) > 1 {
+		sep = memberSeparator(d.data[first.Value.End:root.Members[1].keyStart])
+	}
+	member := Quote(key) + colon + Quote(value)
+
+	for i, m := range root.Members {
+		if m.Key != after {
+			continue
+		}
+		if i+1 < len(root.Members) {
+			next := root.Members[i+1].keyStart
+			return d.Replace(&Value{Start: next, End: next}, member+sep)
+		}
+		// the last member: the new one goes on its own line before the closing brace
+		tail := string(d.data[m.Value.End : root.End-1])
+		end := strings.LastIndexByte(tail, '\n')
+		if end < 0 {
+			return d.Replace(&Value{Start: m.Value.End, End: m.Value.End}, sep+member)
+		}
+		kept := strings.TrimRight(tail[:end], "\r")
+		raw := "," + kept + sep[1:] + member
+		if strings.HasPrefix(strings.TrimSpace(kept), ",") {
+			// a JSONC trailing comma
+			raw = kept + sep[1:] + member + ","
+		}
+		return d.Replace(&Value{Start: m.Value.End, End: m.Value.End + len(kept)}, raw)
+	}
+	return d.Replace(&Value{Start: first.keyStart, End: first.keyStart}, member+sep)
+}
+
+// memberSeparator returns a comma followed by the whitespace before the key in raw,
+// the text between two members or between the opening brace and the first member.
+// Comments of raw are left out.
+func memberSeparator(raw []byte) string {
+	return "," + string(raw[len(bytes.TrimRight(raw, " \t\r\n")):])
+}
+
+// Replace substitutes the raw text of v by raw and re-parses the document.
+// Values obtained before the call are invalidated.
+func (d *Document) Replace(v *Value, raw string) error {
+	data := make([]byte, 0, len(d.data)-(v.End-v.Start)+len(raw))
+	data = append(data, d.data[:v.Start]...)
+	data = append(data, raw...)
+	data = append(data, d.data[v.End:]...)
+
+	doc, err := parse(data, d.jsonc)
+	if err != nil {
+		return err
+	}
+	*d = *doc
+	return nil
+}
+
+// Quote encodes s as a JSON string.
+func Quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
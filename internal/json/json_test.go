@@ -0,0 +1,209 @@
+package json
+
+import (
+	"strings"
+	"testing"
+)
+
+const sample = `{
+  "name": "demo",
+  "engines": { "version": "0.0.1", "node": ">=18" },
+  "version": "1.2.3",
+  "publishConfig": {
+    "version": "9.9.9"
+  },
+  "files": ["a", "bé"],
+  "private": true
+}
+`
+
+func TestGet(t *testing.T) {
+	doc, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if v, ok := doc.GetString("version"); !ok || v != "1.2.3" {
+		t.Errorf("GetString(version) = %q, %v", v, ok)
+	}
+	if v, ok := doc.GetString("publishConfig", "version"); !ok || v != "9.9.9" {
+		t.Errorf("GetString(publishConfig.version) = %q, %v", v, ok)
+	}
+	if files := doc.Get("files"); files == nil || len(files.Items) != 2 || files.Items[1].Text != "bé" {
+		t.Errorf("unexpected files: %+v", files)
+	}
+	if v := doc.Get("private"); v == nil || v.Kind != Bool {
+		t.Errorf("unexpected private: %+v", v)
+	}
+	if doc.Get("missing") != nil {
+		t.Errorf("expected missing key to be nil")
+	}
+}
+
+func TestSetStringPreservesFormatting(t *testing.T) {
+	doc, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.SetString("1.3.0", "version"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	want := strings.Replace(sample, `"version": "1.2.3"`, `"version": "1.3.0"`, 1)
+	if got := string(doc.Bytes()); got != want {
+		t.Fatalf("unexpected document:\n%s", got)
+	}
+}
+
+func TestByteOrderMark(t *testing.T) {
+	in := "\xef\xbb\xbf" + sample
+	doc, err := Parse([]byte(in))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.SetString("1.3.0", "version"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	want := strings.Replace(in, `"version": "1.2.3"`, `"version": "1.3.0"`, 1)
+	if got := string(doc.Bytes()); got != want {
+		t.Fatalf("unexpected document:\n%q", got)
+	}
+}
+
+func TestSetStringMinified(t *testing.T) {
+	in := `{"name":"demo","engines":{"version":"1"},"version":"1.2.3","main":"index.js"}`
+	doc, err := Parse([]byte(in))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.SetString("2.0.0", "version"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	want := `{"name":"demo","engines":{"version":"1"},"version":"2.0.0","main":"index.js"}`
+	if got := string(doc.Bytes()); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestInsertString(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		after string
+		jsonc bool
+		want  string
+	}{
+		{
+			name:  "indented after name",
+			in:    "{\n    \"name\": \"demo\",\n    \"type\": \"library\"\n}\n",
+			after: "name",
+			want:  "{\n    \"name\": \"demo\",\n    \"version\": \"1.0.0\",\n    \"type\": \"library\"\n}\n",
+		},
+		{
+			name:  "minified first",
+			in:    `{"type":"library"}`,
+			after: "name",
+			want:  `{"version":"1.0.0","type":"library"}`,
+		},
+		{
+			name:  "minified last",
+			in:    `{"name":"demo"}`,
+			after: "name",
+			want:  `{"name":"demo","version":"1.0.0"}`,
+		},
+		{
+			name:  "jsonc comment after name",
+			in:    "{\n  \"name\": \"@x/y\", // package name\n  \"exports\": \"./mod.ts\"\n}\n",
+			after: "name",
+			jsonc: true,
+			want:  "{\n  \"name\": \"@x/y\", // package name\n  \"version\": \"1.0.0\",\n  \"exports\": \"./mod.ts\"\n}\n",
+		},
+		{
+			name:  "jsonc comment before first key",
+			in:    "{\n  // the package\n  \"exports\": \"./mod.ts\"\n}\n",
+			after: "name",
+			jsonc: true,
+			want:  "{\n  // the package\n  \"version\": \"1.0.0\",\n  \"exports\": \"./mod.ts\"\n}\n",
+		},
+		{
+			name:  "jsonc comment after last name",
+			in:    "{\r\n  /* the package */\r\n  \"name\": \"@x/y\" // package name\r\n}\r\n",
+			after: "name",
+			jsonc: true,
+			want:  "{\r\n  /* the package */\r\n  \"name\": \"@x/y\", // package name\r\n  \"version\": \"1.0.0\"\r\n}\r\n",
+		},
+		{
+			name:  "jsonc trailing comma",
+			in:    "{\n  \"name\": \"@x/y\",\n}\n",
+			after: "name",
+			jsonc: true,
+			want:  "{\n  \"name\": \"@x/y\",\n  \"version\": \"1.0.0\",\n}\n",
+		},
+		{
+			name: "empty object",
+			in:   `{}`,
+			want: `{"version": "1.0.0"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parse := Parse
+			if tt.jsonc {
+				parse = ParseJSONC
+			}
+			doc, err := parse([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if err := doc.InsertString("version", "1.0.0", tt.after); err != nil {
+				t.Fatalf("insert: %v", err)
+			}
+			if got := string(doc.Bytes()); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		`{"version": "1.2.3"`,
+		`{"version" "1.2.3"}`,
+		`{"version": "1.2.3",}`,
+		`{} {}`,
+	} {
+		if _, err := Parse([]byte(in)); err == nil {
+			t.Errorf("Parse(%q) expected error", in)
+		}
+	}
+}
+
+func TestJSONC(t *testing.T) {
+	in := `// deno configuration
+{
+  /* the package */
+  "name": "@demo/lib", // scoped
+  "version": "1.2.3",
+  "exports": ["./mod.ts",],
+}
+`
+	if _, err := Parse([]byte(in)); err == nil {
+		t.Fatalf("Parse expected error on comments")
+	}
+	doc, err := ParseJSONC([]byte(in))
+	if err != nil {
+		t.Fatalf("ParseJSONC: %v", err)
+	}
+	if v, ok := doc.GetString("version"); !ok || v != "1.2.3" {
+		t.Fatalf("GetString(version) = %q, %v", v, ok)
+	}
+	if err := doc.SetString("1.3.0", "version"); err != nil {
+		t.Fatalf("SetString: %v", err)
+	}
+	want := strings.Replace(in, "1.2.3", "1.3.0", 1)
+	if got := string(doc.Bytes()); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,268 @@
+package json
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// utf8BOM is skipped at the start of a document, the byte order mark is kept when it is written back
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+type parser struct {
+	data []byte
+	pos  int
+	// jsonc allows comments and trailing commas
+	jsonc bool
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	line := 1 + bytes.Count(p.data[:p.pos], []byte("\n"))
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *parser) skipBlank() {
+	for !p.eof() {
+		switch {
+		case strings.IndexByte(" \t\r\n", p.peek()) >= 0:
+			p.pos++
+		case p.jsonc && bytes.HasPrefix(p.data[p.pos:], []byte("//")):
+			end := bytes.IndexByte(p.data[p.pos:], '\n')
+			if end < 0 {
+				p.pos = len(p.data)
+			} else {
+				p.pos += end
+			}
+		case p.jsonc && bytes.HasPrefix(p.data[p.pos:], []byte("/*")):
+			end := bytes.Index(p.data[p.pos+2:], []byte("*/"))
+			if end < 0 {
+				p.pos = len(p.data)
+			} else {
+				p.pos += end + 4
+			}
+		default:
+			return
+		}
+	}
+}
+
+// trailingComma skips blanks after a comma and reports whether close follows, which
+// ends the object or array early in JSONC.
+func (p *parser) trailingComma(close byte) bool {
+	if !p.jsonc {
+		return false
+	}
+	p.skipBlank()
+	return p.peek() == close
+}
+
+func (p *parser) parseValue() (*Value, error) {
+	switch c := p.peek(); {
+	case c == '{':
+		return p.parseObject()
+	case c == '[':
+		return p.parseArray()
+	case c == '"':
+		return p.parseString()
+	case c == '-' || c >= '0' && c <= '9':
+		return p.parseNumber()
+	case c == 't':
+		return p.parseLiteral("true", Bool)
+	case c == 'f':
+		return p.parseLiteral("false", Bool)
+	case c == 'n':
+		return p.parseLiteral("null", Null)
+	case p.eof():
+		return nil, p.errorf("unexpected end of input")
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+func (p *parser) parseObject() (*Value, error) {
+	v := &Value{Kind: Object, Start: p.pos}
+	p.pos++
+	p.skipBlank()
+	if p.peek() == '}' {
+		p.pos++
+		v.End = p.pos
+		return v, nil
+	}
+	for {
+		p.skipBlank()
+		if p.peek() != '"' {
+			return nil, p.errorf("expected object key")
+		}
+		key, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		p.skipBlank()
+		if p.peek() != ':' {
+			return nil, p.errorf("expected ':' after key %q", key.Text)
+		}
+		p.pos++
+		p.skipBlank()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		v.Members = append(v.Members, &Member{Key: key.Text, Value: value, keyStart: key.Start, keyEnd: key.End})
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.pos++
+			if p.trailingComma('}') {
+				p.pos++
+				v.End = p.pos
+				return v, nil
+			}
+		case '}':
+			p.pos++
+			v.End = p.pos
+			return v, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in object")
+		}
+	}
+}
+
+func (p *parser) parseArray() (*Value, error) {
+	v := &Value{Kind: Array, Start: p.pos}
+	p.pos++
+	p.skipBlank()
+	if p.peek() == ']' {
+		p.pos++
+		v.End = p.pos
+		return v, nil
+	}
+	for {
+		p.skipBlank()
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		v.Items = append(v.Items, item)
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.pos++
+			if p.trailingComma(']') {
+				p.pos++
+				v.End = p.pos
+				return v, nil
+			}
+		case ']':
+			p.pos++
+			v.End = p.pos
+			return v, nil
+		default:
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (p *parser) parseString() (*Value, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return nil, p.errorf("unterminated string")
+		}
+		c := p.peek()
+		if c == '"' {
+			p.pos++
+			break
+		}
+		if c < 0x20 {
+			return nil, p.errorf("control character in string")
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			p.pos++
+			continue
+		}
+		p.pos++
+		e := p.peek()
+		p.pos++
+		switch e {
+		case '"', '\\', '/':
+			b.WriteByte(e)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			r, err := p.parseHex4()
+			if err != nil {
+				return nil, err
+			}
+			if utf16.IsSurrogate(r) && bytes.HasPrefix(p.data[p.pos:], []byte(`\u`)) {
+				p.pos += 2
+				r2, err := p.parseHex4()
+				if err != nil {
+					return nil, err
+				}
+				r = utf16.DecodeRune(r, r2)
+			}
+			b.WriteRune(r)
+		default:
+			return nil, p.errorf("invalid escape '\\%c'", e)
+		}
+	}
+	return &Value{Kind: String, Text: b.String(), Start: start, End: p.pos}, nil
+}
+
+func (p *parser) parseHex4() (rune, error) {
+	if p.pos+4 > len(p.data) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	n, err := strconv.ParseUint(string(p.data[p.pos:p.pos+4]), 16, 16)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos += 4
+	return rune(n), nil
+}
+
+func (p *parser) parseNumber() (*Value, error) {
+	start := p.pos
+	for !p.eof() && strings.IndexByte("+-.eE0123456789", p.peek()) >= 0 {
+		p.pos++
+	}
+	text := string(p.data[start:p.pos])
+	if _, err := strconv.ParseFloat(text, 64); err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number %q", text)
+	}
+	return &Value{Kind: Number, Text: text, Start: start, End: p.pos}, nil
+}
+
+func (p *parser) parseLiteral(literal string, kind Kind) (*Value, error) {
+	if !bytes.HasPrefix(p.data[p.pos:], []byte(literal)) {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+	start := p.pos
+	p.pos += len(literal)
+	return &Value{Kind: kind, Text: literal, Start: start, End: p.pos}, nil
+}
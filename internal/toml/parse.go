@@ -0,0 +1,435 @@
+package toml
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// utf8BOM is the byte order mark some Windows editors write, it is skipped by the parser but stays in the document
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+type parser struct {
+	data   []byte
+	pos    int
+	doc    *Document
+	table  []string
+	index  int
+	arrays map[string]int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	line := 1 + bytes.Count(p.data[:p.pos], []byte("\n"))
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *parser) hasPrefix(s string) bool {
+	return bytes.HasPrefix(p.data[p.pos:], []byte(s))
+}
+
+// skipSpace skips spaces and tabs on the current line.
+func (p *parser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank skips whitespace, newlines and comments.
+func (p *parser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) skipComment() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+// endOfLine expects only whitespace or a comment up to the end of the line.
+func (p *parser) endOfLine() error {
+	p.skipSpace()
+	if p.peek() == '#' {
+		p.skipComment()
+	}
+	if p.hasPrefix("\r\n") {
+		p.pos += 2
+		return nil
+	}
+	if p.eof() || p.peek() == '\n' {
+		p.pos++
+		return nil
+	}
+	return p.errorf("unexpected %q", p.peek())
+}
+
+func (p *parser) parse() error {
+	p.arrays = map[string]int{}
+	if bytes.HasPrefix(p.data, utf8BOM) {
+		p.pos = len(utf8BOM)
+	}
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+		var err error
+		if p.peek() == '[' {
+			err = p.parseHeader()
+		} else {
+			err = p.parseKeyValue(p.table)
+			if err == nil {
+				err = p.endOfLine()
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) parseHeader() error {
+	array := p.hasPrefix("[[")
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+	p.skipSpace()
+	key, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if array {
+		if !p.hasPrefix("]]") {
+			return p.errorf("expected ']]'")
+		}
+		p.pos += 2
+		name := strings.Join(key, "\x00")
+		p.index = p.arrays[name]
+		p.arrays[name]++
+	} else {
+		if p.peek() != ']' {
+			return p.errorf("expected ']'")
+		}
+		p.pos++
+		p.index = -1
+	}
+	p.table = key
+	p.doc.tables = append(p.doc.tables, key)
+	return p.endOfLine()
+}
+
+func (p *parser) parseKeyValue(prefix []string) error {
+	key, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after key %s", strings.Join(key, "."))
+	}
+	p.pos++
+	p.skipSpace()
+
+	path := append(append([]string{}, prefix...), key...)
+	entry := &Entry{Path: path, Table: p.table, Index: p.index}
+	// register the entry before parsing the value so inline table members follow their parent
+	p.doc.entries = append(p.doc.entries, entry)
+	entry.Value, err = p.parseValue(path)
+	return err
+}
+
+// parseKey parses a (dotted) key made of bare, basic or literal parts.
+func (p *parser) parseKey() ([]string, error) {
+	var key []string
+	for {
+		p.skipSpace()
+		var part string
+		switch c := p.peek(); {
+		case c == '"':
+			v, err := p.parseBasicString()
+			if err != nil {
+				return nil, err
+			}
+			part = v.Text
+		case c == '\'':
+			v, err := p.parseLiteralString()
+			if err != nil {
+				return nil, err
+			}
+			part = v.Text
+		case isBareKeyChar(c):
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			part = string(p.data[start:p.pos])
+		default:
+			return nil, p.errorf("invalid key character %q", c)
+		}
+		key = append(key, part)
+		p.skipSpace()
+		if p.peek() != '.' {
+			return key, nil
+		}
+		p.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *parser) parseValue(path []string) (*Value, error) {
+	switch {
+	case p.hasPrefix(`"""`):
+		return p.parseMultilineString(`"""`)
+	case p.hasPrefix(`'''`):
+		return p.parseMultilineString(`'''`)
+	case p.peek() == '"':
+		return p.parseBasicString()
+	case p.peek() == '\'':
+		return p.parseLiteralString()
+	case p.peek() == '[':
+		return p.parseArray()
+	case p.peek() == '{':
+		return p.parseInlineTable(path)
+	default:
+		return p.parseScalar()
+	}
+}
+
+func (p *parser) parseBasicString() (*Value, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return nil, p.errorf("unterminated string")
+		}
+		c := p.peek()
+		if c == '"' {
+			p.pos++
+			break
+		}
+		if c == '\\' {
+			if err := p.parseEscape(&b); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+	return &Value{Kind: String, Text: b.String(), Start: start, End: p.pos, quote: `"`}, nil
+}
+
+func (p *parser) parseLiteralString() (*Value, error) {
+	start := p.pos
+	p.pos++
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return nil, p.errorf("unterminated string")
+		}
+		if p.peek() == '\'' {
+			p.pos++
+			break
+		}
+		p.pos++
+	}
+	text := string(p.data[start+1 : p.pos-1])
+	return &Value{Kind: String, Text: text, Start: start, End: p.pos, quote: `'`}, nil
+}
+
+func (p *parser) parseMultilineString(quote string) (*Value, error) {
+	start := p.pos
+	p.pos += len(quote)
+	// a newline immediately following the opening delimiter is trimmed
+	if p.hasPrefix("\r\n") {
+		p.pos += 2
+	} else if p.peek() == '\n' {
+		p.pos++
+	}
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return nil, p.errorf("unterminated multi-line string")
+		}
+		if p.hasPrefix(quote) {
+			// up to two quotes may directly precede the closing delimiter
+			for p.hasPrefix(quote + quote[:1]) {
+				b.WriteByte(quote[0])
+				p.pos++
+			}
+			p.pos += len(quote)
+			break
+		}
+		if quote == `"""` && p.peek() == '\\' {
+			if err := p.parseEscape(&b); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		b.WriteByte(p.peek())
+		p.pos++
+	}
+	return &Value{Kind: String, Text: b.String(), Start: start, End: p.pos, quote: quote}, nil
+}
+
+func (p *parser) parseEscape(b *strings.Builder) error {
+	p.pos++
+	if p.eof() {
+		return p.errorf("unterminated escape")
+	}
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case 'e':
+		b.WriteByte(0x1b)
+	case '"':
+		b.WriteByte('"')
+	case '\\':
+		b.WriteByte('\\')
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if p.pos+n > len(p.data) {
+			return p.errorf("invalid unicode escape")
+		}
+		r, err := strconv.ParseUint(string(p.data[p.pos:p.pos+n]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			return p.errorf("invalid unicode escape")
+		}
+		b.WriteRune(rune(r))
+		p.pos += n
+	case ' ', '\t', '\r', '\n':
+		// line ending backslash, only valid in multi-line strings
+		p.pos--
+		p.skipBlankNoComment()
+	default:
+		return p.errorf("invalid escape '\\%c'", c)
+	}
+	return nil
+}
+
+func (p *parser) skipBlankNoComment() {
+	for !p.eof() && strings.IndexByte(" \t\r\n", p.peek()) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *parser) parseArray() (*Value, error) {
+	v := &Value{Kind: Array, Start: p.pos}
+	p.pos++
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil, p.errorf("unterminated array")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			break
+		}
+		// elements of arrays are not addressable by key, so inline tables in arrays get no path
+		item, err := p.parseValue(nil)
+		if err != nil {
+			return nil, err
+		}
+		v.Items = append(v.Items, item)
+		p.skipBlank()
+		if p.peek() == ',' {
+			p.pos++
+			continue
+		}
+		if p.peek() != ']' {
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+	v.End = p.pos
+	return v, nil
+}
+
+func (p *parser) parseInlineTable(path []string) (*Value, error) {
+	v := &Value{Kind: InlineTable, Start: p.pos}
+	p.pos++
+	entries := p.doc.entries
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil, p.errorf("unterminated inline table")
+		}
+		if p.peek() == '}' {
+			p.pos++
+			break
+		}
+		if err := p.parseKeyValue(path); err != nil {
+			return nil, err
+		}
+		p.skipBlank()
+		if p.peek() == ',' {
+			p.pos++
+			continue
+		}
+		if p.peek() != '}' {
+			return nil, p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+	if path == nil {
+		// members of inline tables nested in arrays are not addressable
+		p.doc.entries = entries
+	}
+	v.End = p.pos
+	return v, nil
+}
+
+func (p *parser) parseScalar() (*Value, error) {
+	start := p.pos
+	for !p.eof() && strings.IndexByte(",]}#\r\n", p.peek()) < 0 {
+		p.pos++
+	}
+	text := strings.TrimRight(string(p.data[start:p.pos]), " \t")
+	if text == "" {
+		return nil, p.errorf("missing value")
+	}
+	p.pos = start + len(text)
+	kind := Scalar
+	if text == "true" || text == "false" {
+		kind = Bool
+	}
+	return &Value{Kind: kind, Text: text, Start: start, End: p.pos}, nil
+}
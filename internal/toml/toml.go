@@ -0,0 +1,252 @@
+// Package toml is a small, format preserving TOML reader/writer.
+//
+// It is not a general purpose decoder: it only records where every key/value
+// pair lives in the original bytes, so a value can be looked up by its table and
+// key and replaced in place while comments, ordering and formatting of the rest
+// of the document stay untouched.
+package toml
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Kind is the type of a TOML value.
+type Kind int
+
+const (
+	String Kind = iota
+	Bool
+	// Scalar is any other bare value (integers, floats, dates), kept as raw text
+	Scalar
+	Array
+	InlineTable
+)
+
+// Value is a parsed TOML value with its location in the document.
+type Value struct {
+	Kind Kind
+	// Text is the decoded content of strings, or the raw text of other scalars
+	Text string
+	// Items holds the elements of an array
+	Items []*Value
+	// Start and End are the byte offsets of the raw value in the document
+	Start int
+	End   int
+	quote string
+}
+
+// Entry is a key/value pair of the document.
+type Entry struct {
+	// Path is the full key path: the table header followed by the (dotted) key
+	Path []string
+	// Table is the header of the table the entry is defined in
+	Table []string
+	// Index is the occurrence of the [[array table]] the entry belongs to, -1 for plain tables
+	Index int
+	Value *Value
+}
+
+// Document is a parsed TOML file.
+type Document struct {
+	data    []byte
+	tables  [][]string
+	entries []*Entry
+}
+
+// Load reads and parses fileName.
+func Load(fileName string) (*Document, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", fileName, err)
+	}
+	return doc, nil
+}
+
+// Parse parses data as a TOML document.
+func Parse(data []byte) (*Document, error) {
+	p := &parser{data: data, doc: &Document{data: data}, index: -1}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.doc, nil
+}
+
+// Bytes returns the current content of the document.
+func (d *Document) Bytes() []byte {
+	return d.data
+}
+
+// Save writes the document to fileName, keeping the mode of an existing file.
+func (d *Document) Save(fileName string) error {
+	var mode os.FileMode = 0o644
+	if stat, err := os.Stat(fileName); err == nil {
+		mode = stat.Mode()
+	}
+	return os.WriteFile(fileName, d.data, mode)
+}
+
+// Entries returns all key/value pairs in document order.
+func (d *Document) Entries() []*Entry {
+	return d.entries
+}
+
+// Get returns the value at the full key path, e.g. Get("project", "version"),
+// or nil if it is not defined. For array tables the first occurrence wins.
+func (d *Document) Get(path ...string) *Value {
+	for _, e := range d.entries {
+		if samePath(e.Path, path) {
+			return e.Value
+		}
+	}
+	return nil
+}
+
+// GetString returns the string at the full key path.
+func (d *Document) GetString(path ...string) (string, bool) {
+	v := d.Get(path...)
+	if v == nil || v.Kind != String {
+		return "", false
+	}
+	return v.Text, true
+}
+
+// HasTable reports whether the table is declared by a header or by any key under it.
+func (d *Document) HasTable(path ...string) bool {
+	for _, t := range d.tables {
+		if hasPrefix(t, path) {
+			return true
+		}
+	}
+	for _, e := range d.entries {
+		if len(e.Path) > len(path) && hasPrefix(e.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ArrayTable returns the entries of every [[array table]] named by path, one slice per occurrence.
+func (d *Document) ArrayTable(path ...string) [][]*Entry {
+	var tables [][]*Entry
+	for _, e := range d.entries {
+		if e.Index < 0 || !samePath(e.Table, path) {
+			continue
+		}
+		for len(tables) <= e.Index {
+			tables = append(tables, nil)
+		}
+		tables[e.Index] = append(tables[e.Index], e)
+	}
+	return tables
+}
+
+// SetString replaces the string at the full key path, keeping its quoting style.
+func (d *Document) SetString(value string, path ...string) error {
+	v := d.Get(path...)
+	if v == nil {
+		return fmt.Errorf("key %s not found", strings.Join(path, "."))
+	}
+	if v.Kind != String {
+		return fmt.Errorf("key %s is not a string", strings.Join(path, "."))
+	}
+	return d.Replace(v, v.Quote(value))
+}
+
+// Replace substitutes the raw text of v by raw and re-parses the document.
+// Values obtained before the call are invalidated.
+func (d *Document) Replace(v *Value, raw string) error {
+	return d.Apply(Edit{Value: v, Raw: raw})
+}
+
+// Edit is a pending replacement of the raw text of a value.
+type Edit struct {
+	Value *Value
+	Raw   string
+}
+
+// Apply performs non overlapping edits at once and re-parses the document.
+// Values obtained before the call are invalidated.
+func (d *Document) Apply(edits ...Edit) error {
+	edits = slices.Clone(edits)
+	slices.SortFunc(edits, func(a, b Edit) int {
+		return a.Value.Start - b.Value.Start
+	})
+
+	var data []byte
+	last := 0
+	for _, e := range edits {
+		if e.Value.Start < last {
+			return fmt.Errorf("overlapping edits at offset %d", e.Value.Start)
+		}
+		data = append(data, d.data[last:e.Value.Start]...)
+		data = append(data, e.Raw...)
+		last = e.Value.End
+	}
+	data = append(data, d.data[last:]...)
+
+	doc, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	*d = *doc
+	return nil
+}
+
+// Quote encodes s as a TOML string in the quoting style of v when possible.
+func (v *Value) Quote(s string) string {
+	return Quote(s, v.quote)
+}
+
+// Quote encodes s as a TOML string, using the given quote style when possible.
+func Quote(s, quote string) string {
+	if quote == "'" && !strings.ContainsAny(s, "'\n\r") {
+		return "'" + s + "'"
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func samePath(a, b []string) bool {
+	return len(a) == len(b) && hasPrefix(a, b)
+}
+
+func hasPrefix(path, prefix []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package toml
+
+import (
+	"strings"
+	"testing"
+)
+
+const sample = `# top comment
+name = "top"
+
+[tool.something]
+version = "9.9.9" # not the one
+
+[dependencies]
+foo = { version = "1.0", path = "../foo" }
+bar.version = '2.0'
+
+[project]
+description = """
+version = "0.0.0"
+"""
+version   =   "1.2.3"   # keep me
+tags = [
+  "a", # first
+  "b",
+]
+
+[[package]]
+name = "x"
+version = "0.1.0"
+
+[[package]]
+name = "y"
+version = "0.2.0"
+`
+
+func TestGet(t *testing.T) {
+	doc, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		path []string
+		want string
+	}{
+		{[]string{"name"}, "top"},
+		{[]string{"tool", "something", "version"}, "9.9.9"},
+		{[]string{"dependencies", "foo", "version"}, "1.0"},
+		{[]string{"dependencies", "bar", "version"}, "2.0"},
+		{[]string{"project", "version"}, "1.2.3"},
+		{[]string{"project", "description"}, "version = \"0.0.0\"\n"},
+	}
+	for _, tt := range tests {
+		got, ok := doc.GetString(tt.path...)
+		if !ok || got != tt.want {
+			t.Errorf("GetString(%v) = %q, %v, want %q", tt.path, got, ok, tt.want)
+		}
+	}
+
+	tags := doc.Get("project", "tags")
+	if tags == nil || tags.Kind != Array || len(tags.Items) != 2 || tags.Items[1].Text != "b" {
+		t.Errorf("unexpected tags: %+v", tags)
+	}
+
+	if !doc.HasTable("tool") || doc.HasTable("workspace") {
+		t.Errorf("unexpected HasTable result")
+	}
+
+	packages := doc.ArrayTable("package")
+	if len(packages) != 2 || packages[1][1].Value.Text != "0.2.0" {
+		t.Errorf("unexpected array tables: %+v", packages)
+	}
+}
+
+func TestSetStringPreservesFormatting(t *testing.T) {
+	doc, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if err := doc.SetString("1.3.0", "project", "version"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := doc.SetString("2.1", "dependencies", "bar", "version"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	want := sample
+	want = strings.Replace(want, `version   =   "1.2.3"`, `version   =   "1.3.0"`, 1)
+	want = strings.Replace(want, `bar.version = '2.0'`, `bar.version = '2.1'`, 1)
+	if got := string(doc.Bytes()); got != want {
+		t.Fatalf("unexpected document:\n%s", got)
+	}
+}
+
+func TestByteOrderMark(t *testing.T) {
+	in := "\xef\xbb\xbf" + sample
+	doc, err := Parse([]byte(in))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.SetString("1.3.0", "project", "version"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	want := strings.Replace(in, `version   =   "1.2.3"`, `version   =   "1.3.0"`, 1)
+	if got := string(doc.Bytes()); got != want {
+		t.Fatalf("unexpected document:\n%q", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		`version = "1.2.3`,
+		`[package`,
+		`version "1.2.3"`,
+		`a = [1, 2`,
+	} {
+		if _, err := Parse([]byte(in)); err == nil {
+			t.Errorf("Parse(%q) expected error", in)
+		}
+	}
+}
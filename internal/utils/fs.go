@@ -53,3 +53,20 @@ func FindFileDown(startDir, fileName string) (string, bool) {
 
 	return "", false
 }
+
+// FindFileUp searches for a file named fileName starting from startDir and walking up to the filesystem root.
+// Returns the full path to the file and true if found, otherwise returns an empty string and false.
+func FindFileUp(startDir, fileName string) (string, bool) {
+	dir := path.Clean(startDir)
+	for {
+		fullPath := path.Join(dir, fileName)
+		if FileExists(fullPath) {
+			return fullPath, true
+		}
+		parent := path.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
@@ -36,3 +36,20 @@ func Grep(fileName string, re *regexp.Regexp) (string, error) {
 	}
 	return "", fmt.Errorf("multiple results found, which may be unexpected. ensure your regex has at most one capture group")
 }
+
+// GrepAll searches fileName for all matches of the provided regular expression, which
+// should have one capture group, and returns the captured content of each match.
+func GrepAll(fileName string, re *regexp.Regexp) ([]string, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, found := range re.FindAllSubmatch(data, -1) {
+		if len(found) != 2 {
+			return nil, fmt.Errorf("ensure your regex has exactly one capture group")
+		}
+		values = append(values, string(found[1]))
+	}
+	return values, nil
+}
@@ -56,3 +56,37 @@ func Sed(fileName string, re *regexp.Regexp, replace string) error {
 	}
 	return nil
 }
+
+// SedAll replaces the capture group of every match of the regular expression in the specified file.
+func SedAll(fileName string, re *regexp.Regexp, replace string) error {
+	stat, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+	if stat.IsDir() {
+		return fmt.Errorf("%s should be a file", fileName)
+	}
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	indexes := re.FindAllSubmatchIndex(data, -1)
+	if len(indexes) == 0 {
+		return fmt.Errorf("pattern %s not found in %s", re.String(), fileName)
+	}
+
+	var out []byte
+	last := 0
+	for _, index := range indexes {
+		if len(index) != 4 {
+			return fmt.Errorf("ensure your regex has exactly one capture group")
+		}
+		out = append(out, data[last:index[2]]...)
+		out = append(out, replace...)
+		last = index[3]
+	}
+	out = append(out, data[last:]...)
+
+	return os.WriteFile(fileName, out, stat.Mode())
+}
@@ -37,3 +37,37 @@ func TestSed(t *testing.T) {
 	}
 
 }
+
+func TestSedAll(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	filePath := path.Join(tmpDir, "project.pbxproj")
+
+	originalContent := "MARKETING_VERSION = 1.0;\nOTHER = 1.0;\nMARKETING_VERSION = 1.0;\n"
+	err := os.WriteFile(filePath, []byte(originalContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	re := regexp.MustCompile(`MARKETING_VERSION = ([^;]+);`)
+
+	values, err := GrepAll(filePath, re)
+	if err != nil || len(values) != 2 || values[0] != "1.0" {
+		t.Fatalf("GrepAll returned %v, %v", values, err)
+	}
+
+	err = SedAll(filePath, re, "1.1.0")
+	if err != nil {
+		t.Fatalf("SedAll failed: %v", err)
+	}
+
+	updatedContent, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read updated file: %v", err)
+	}
+
+	expectedContent := "MARKETING_VERSION = 1.1.0;\nOTHER = 1.0;\nMARKETING_VERSION = 1.1.0;\n"
+	if string(updatedContent) != expectedContent {
+		t.Errorf("SedAll did not update content as expected.\nGot:\n%s\nExpected:\n%s", string(updatedContent), expectedContent)
+	}
+}
@@ -0,0 +1,236 @@
+// Package xml is a small, format preserving XML reader/writer.
+//
+// It uses encoding/xml to walk the document and records where the text of every
+// element lives in the original bytes, so it can be replaced in place while the
+// rest of the document stays untouched.
+package xml
+
+import (
+	"bytes"
+	stdxml "encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Element is an element of the document.
+type Element struct {
+	// Path is the local names of the element and its ancestors, from the root
+	Path []string
+	// Attrs are the attributes of the element
+	Attrs []stdxml.Attr
+	// Text is the trimmed text content, empty for elements with children
+	Text string
+	// HasChildren reports whether the element contains child elements
+	HasChildren bool
+	// TagStart and TagEnd are the byte offsets of the start tag
+	TagStart int
+	TagEnd   int
+	// Start and End are the byte offsets of the trimmed text content
+	Start int
+	End   int
+	// tag and name are the raw start tag and the qualified name, to expand self-closing elements
+	tag  string
+	name string
+}
+
+// Attr returns the value of the attribute with the given local name.
+func (e *Element) Attr(name string) (string, bool) {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// Document is a parsed XML file.
+type Document struct {
+	data     []byte
+	elements []*Element
+}
+
+// Load reads and parses fileName.
+func Load(fileName string) (*Document, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", fileName, err)
+	}
+	return doc, nil
+}
+
+// Parse parses data as an XML document.
+func Parse(data []byte) (*Document, error) {
+	doc := &Document{data: data}
+	dec := stdxml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+
+	var stack []*Element
+	var path []string
+	for {
+		offset := int(dec.InputOffset())
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case stdxml.StartElement:
+			if len(stack) > 0 {
+				stack[len(stack)-1].HasChildren = true
+			}
+			path = append(path, t.Name.Local)
+			e := &Element{
+				Path:     slices.Clone(path),
+				Attrs:    t.Attr,
+				TagStart: offset,
+				TagEnd:   int(dec.InputOffset()),
+				name:     t.Name.Local,
+			}
+			if t.Name.Space != "" {
+				e.name = t.Name.Space + ":" + t.Name.Local
+			}
+			e.tag = string(data[e.TagStart:e.TagEnd])
+			e.Start, e.End = e.TagEnd, e.TagEnd
+			doc.elements = append(doc.elements, e)
+			stack = append(stack, e)
+		case stdxml.EndElement:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("unexpected end element %s", t.Name.Local)
+			}
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			path = path[:len(path)-1]
+			if e.HasChildren {
+				continue
+			}
+			raw := data[e.TagEnd:offset]
+			trimmed := bytes.TrimSpace(raw)
+			e.Start = e.TagEnd + bytes.Index(raw, trimmed)
+			e.End = e.Start + len(trimmed)
+			e.Text = unescape(trimmed)
+		}
+	}
+	if len(stack) > 0 {
+		e := stack[len(stack)-1]
+		return nil, fmt.Errorf("element %s is not closed", e.Path[len(e.Path)-1])
+	}
+	return doc, nil
+}
+
+func unescape(raw []byte) string {
+	var b strings.Builder
+	dec := stdxml.NewDecoder(bytes.NewReader(raw))
+	dec.Strict = false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		if cd, ok := tok.(stdxml.CharData); ok {
+			b.Write(cd)
+		}
+	}
+	return b.String()
+}
+
+// Bytes returns the current content of the document.
+func (d *Document) Bytes() []byte {
+	return d.data
+}
+
+// Save writes the document to fileName, keeping the mode of an existing file.
+func (d *Document) Save(fileName string) error {
+	var mode os.FileMode = 0o644
+	if stat, err := os.Stat(fileName); err == nil {
+		mode = stat.Mode()
+	}
+	return os.WriteFile(fileName, d.data, mode)
+}
+
+// Elements returns all elements in document order.
+func (d *Document) Elements() []*Element {
+	return d.elements
+}
+
+// Find returns the elements at the exact path from the root, e.g.
+// Find("project", "version") never returns a dependency version.
+func (d *Document) Find(path ...string) []*Element {
+	var found []*Element
+	for _, e := range d.elements {
+		if slices.Equal(e.Path, path) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// First returns the first element at the exact path from the root, or nil.
+func (d *Document) First(path ...string) *Element {
+	for _, e := range d.elements {
+		if slices.Equal(e.Path, path) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Edit is a pending replacement of a byte range of the document.
+type Edit struct {
+	Start int
+	End   int
+	Raw   string
+}
+
+// SetText returns the edit replacing the text content of e by text.
+func SetText(e *Element, text string) Edit {
+	if strings.HasSuffix(e.tag, "/>") {
+		// <Name/> becomes <Name>text</Name>
+		open := strings.TrimRight(strings.TrimSuffix(e.tag, "/>"), " \t\r\n")
+		return Edit{Start: e.TagStart, End: e.TagEnd, Raw: open + ">" + Escape(text) + "</" + e.name + ">"}
+	}
+	return Edit{Start: e.Start, End: e.End, Raw: Escape(text)}
+}
+
+// Apply performs non overlapping edits at once and re-parses the document.
+// Elements obtained before the call are invalidated.
+func (d *Document) Apply(edits ...Edit) error {
+	edits = slices.Clone(edits)
+	slices.SortFunc(edits, func(a, b Edit) int {
+		return a.Start - b.Start
+	})
+
+	var data []byte
+	last := 0
+	for _, e := range edits {
+		if e.Start < last {
+			return fmt.Errorf("overlapping edits at offset %d", e.Start)
+		}
+		data = append(data, d.data[last:e.Start]...)
+		data = append(data, e.Raw...)
+		last = e.End
+	}
+	data = append(data, d.data[last:]...)
+
+	doc, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	*d = *doc
+	return nil
+}
+
+// Escape encodes s as XML character data.
+func Escape(s string) string {
+	var b strings.Builder
+	stdxml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package xml
+
+import (
+	"strings"
+	"testing"
+)
+
+const sample = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- a comment with <version>0.0.0</version> -->
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <parent>
+    <version>9.9.9</version>
+  </parent>
+  <version>
+    1.2.3-SNAPSHOT
+  </version>
+  <name>a &amp; b</name>
+  <dependencies>
+    <dependency><version>2.0</version></dependency>
+  </dependencies>
+  <empty/>
+</project>
+`
+
+func TestFind(t *testing.T) {
+	doc, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if e := doc.First("project", "version"); e == nil || e.Text != "1.2.3-SNAPSHOT" {
+		t.Errorf("unexpected project version: %+v", e)
+	}
+	if e := doc.First("project", "name"); e == nil || e.Text != "a & b" {
+		t.Errorf("unexpected name: %+v", e)
+	}
+	if e := doc.First("project", "parent"); e == nil || !e.HasChildren {
+		t.Errorf("expected parent to have children: %+v", e)
+	}
+	if e := doc.First("project", "empty"); e == nil || e.Text != "" {
+		t.Errorf("unexpected empty element: %+v", e)
+	}
+	if got := len(doc.Find("project", "dependencies", "dependency", "version")); got != 1 {
+		t.Errorf("expected 1 dependency version, got %d", got)
+	}
+}
+
+func TestApplyPreservesFormatting(t *testing.T) {
+	doc, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.Apply(SetText(doc.First("project", "version"), "1.3.0")); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	want := strings.Replace(sample, "    1.2.3-SNAPSHOT\n", "    1.3.0\n", 1)
+	if got := string(doc.Bytes()); got != want {
+		t.Fatalf("unexpected document:\n%s", got)
+	}
+}
+
+func TestSetTextSelfClosing(t *testing.T) {
+	doc, err := Parse([]byte(`<Project><VersionSuffix /></Project>`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := doc.Apply(SetText(doc.First("Project", "VersionSuffix"), "rc.1")); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if got, want := string(doc.Bytes()), `<Project><VersionSuffix>rc.1</VersionSuffix></Project>`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		`<project><version>1.0</project>`,
+		`<project>`,
+	} {
+		if _, err := Parse([]byte(in)); err == nil {
+			t.Errorf("Parse(%q) expected error", in)
+		}
+	}
+}
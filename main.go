@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	flag "github.com/spf13/pflag"
 
@@ -25,6 +27,17 @@ var flagHelp bool
 var flagVerbose bool
 var flagGitTag bool
 var flagGitTagPush bool
+var flagCrate string
+var flagListCrates bool
+var flagVersionCode string
+var flagChartAppVersion string
+var flagGoMajorPath bool
+var flagGoVersionName string
+var flagLDFlags string
+var flagLDFlagsFile string
+
+// buildinfoPackage is the default package stamped by --ldflags
+const buildinfoPackage = "github.com/elsejj/verit/pkg/buildinfo"
 
 //go:embed version.txt
 var ver string
@@ -51,7 +64,17 @@ func initFlags() {
 	flag.BoolVarP(&flagAppVersion, "app-version", "V", false, "show app version")
 	flag.BoolVarP(&flagGitTag, "tag", "t", false, "create git tag using current version")
 	flag.BoolVarP(&flagGitTagPush, "tag-push", "T", false, "create git tag and push it with --force")
+	flag.StringVar(&flagCrate, "crate", "", "select a crate by name in a Cargo workspace with independent crate versions")
+	flag.BoolVar(&flagListCrates, "crates", false, "list the crates of the Cargo workspace with their versions")
+	flag.StringVar(&flagVersionCode, "version-code", "increment", "how the build number of Android, Flutter and Xcode apps follows the version: 'increment', 'git' (commit count), 'timestamp' or a formula like 'major*10000+minor*100+patch'")
+	flag.StringVar(&flagChartAppVersion, "chart-app-version", "", "keep appVersion of Chart.yaml in lockstep with the version of the project in this directory, relative to the chart, '.' for the chart version itself")
+	flag.BoolVar(&flagGoMajorPath, "go-major-path", false, "move a Go module to the /vN path of a new major version, rewriting go.mod and imports")
+	flag.StringVar(&flagGoVersionName, "go-version-name", "Version", "name of the Go string constant or variable holding the version when there is no version.txt")
 
+	flag.StringVar(&flagLDFlags, "ldflags", "", "show the go -ldflags setting Version, Commit and Date of this package instead of the version, "+buildinfoPackage+" if no value")
+	flag.StringVar(&flagLDFlagsFile, "ldflags-file", "", "write the go -ldflags to this file instead of showing them")
+
+	flag.Lookup("ldflags").NoOptDefVal = buildinfoPackage
 	flag.Lookup("major").NoOptDefVal = "INC"
 	flag.Lookup("minor").NoOptDefVal = "INC"
 	flag.Lookup("patch").NoOptDefVal = "INC"
@@ -85,13 +108,29 @@ func main() {
 
 	id := projectid.Which(workdir)
 
-	p := id.Project(workdir)
+	opts := &projectid.Options{
+		Crate:           flagCrate,
+		VersionCode:     flagVersionCode,
+		CreateVersion:   len(flagSetVersion) > 0,
+		ChartAppVersion: flagChartAppVersion,
+		GoMajorPath:     flagGoMajorPath,
+		GoVersionName:   flagGoVersionName,
+		Warnf: func(format string, args ...any) {
+			fmt.Printf("warning: "+format+"\n", args...)
+		},
+	}
+	p := id.ProjectWith(workdir, opts)
 
 	if p == nil {
 		fmt.Println("unsupported project in", workdir)
 		return
 	}
 
+	if flagListCrates {
+		listCrates(p)
+		return
+	}
+
 	if len(flagSetVersion) > 0 {
 		v, err := version.Parse(flagSetVersion)
 		if err != nil {
@@ -131,6 +170,11 @@ func main() {
 		}
 	}
 
+	if len(flagLDFlags) > 0 || len(flagLDFlagsFile) > 0 {
+		showLDFlags(p)
+		return
+	}
+
 	showVersion(p)
 }
 
@@ -224,3 +268,56 @@ func showVersion(p projectid.Project) {
 		fmt.Println(v)
 	}
 }
+
+func listCrates(p projectid.Project) {
+	rp, ok := p.(*projectid.RustProject)
+	if !ok {
+		fmt.Printf("'%s' project in '%s' is not a Cargo workspace\n", p.ID(), p.WorkDir())
+		return
+	}
+	crates, err := rp.Crates()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, c := range crates {
+		if flagVerbose {
+			fmt.Printf("%s %s (%s)\n", c.Name, c.Version, c.Dir)
+		} else {
+			fmt.Println(c.Name, c.Version)
+		}
+	}
+}
+
+func showLDFlags(p projectid.Project) {
+	v, err := p.GetVersion()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	pkg := flagLDFlags
+	if len(pkg) == 0 {
+		pkg = buildinfoPackage
+	}
+	ldflags := fmt.Sprintf("-X %s.Version=%s", pkg, v)
+	commit, date, err := git.Head(p.WorkDir())
+	if err != nil {
+		if flagVerbose {
+			fmt.Println("no git commit to stamp:", err)
+		}
+	} else {
+		ldflags += fmt.Sprintf(" -X %s.Commit=%s -X %s.Date=%s", pkg, commit, pkg, date.UTC().Format(time.RFC3339))
+	}
+
+	if len(flagLDFlagsFile) == 0 {
+		fmt.Println(ldflags)
+		return
+	}
+	if err := os.WriteFile(flagLDFlagsFile, []byte(ldflags+"\n"), 0o644); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if flagVerbose {
+		fmt.Printf("ldflags written to '%s'\n", flagLDFlagsFile)
+	}
+}
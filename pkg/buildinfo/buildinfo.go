@@ -0,0 +1,76 @@
+// Package buildinfo holds the version of a binary, stamped at build time with
+//
+//	go build -ldflags "$(verit --ldflags)"
+//
+// When the binary is built without them, the values recorded by the go command,
+// from the module version and the version control information, are used instead.
+package buildinfo
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// set by -ldflags "-X github.com/elsejj/verit/pkg/buildinfo.Version=..."
+var (
+	// Version is the version of the project, like `1.2.3`
+	Version string
+	// Commit is the hash of the git commit the binary is built from
+	Commit string
+	// Date is the time of the commit, in RFC 3339 format
+	Date string
+)
+
+// Info is the version of a binary.
+type Info struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// Get returns the values stamped by -ldflags, falling back to the ones of debug.ReadBuildInfo.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, Date: Date}
+	if info.Version != "" && info.Commit != "" && info.Date != "" {
+		return info
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	return fill(info, bi)
+}
+
+// fill sets the empty fields of info from bi
+func fill(info Info, bi *debug.BuildInfo) Info {
+	if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = strings.TrimPrefix(bi.Main.Version, "v")
+	}
+	for _, s := range bi.Settings {
+		switch {
+		case s.Key == "vcs.revision" && info.Commit == "":
+			info.Commit = s.Value
+		case s.Key == "vcs.time" && info.Date == "":
+			info.Date = s.Value
+		}
+	}
+	return info
+}
+
+// String returns the version followed by the short commit and the date, like `1.2.3 (0123abc 2024-05-01T10:00:00Z)`.
+func (i Info) String() string {
+	var b strings.Builder
+	b.WriteString(i.Version)
+	if b.Len() == 0 {
+		b.WriteString("unknown")
+	}
+	commit := i.Commit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	details := strings.TrimSpace(commit + " " + i.Date)
+	if details != "" {
+		b.WriteString(" (" + details + ")")
+	}
+	return b.String()
+}
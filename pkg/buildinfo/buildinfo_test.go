@@ -0,0 +1,37 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestFill(t *testing.T) {
+	bi := &debug.BuildInfo{
+		Main: debug.Module{Path: "example.com/demo", Version: "v1.2.3"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: "0123456789abcdef"},
+			{Key: "vcs.time", Value: "2024-05-01T10:00:00Z"},
+		},
+	}
+
+	got := fill(Info{}, bi)
+	want := Info{Version: "1.2.3", Commit: "0123456789abcdef", Date: "2024-05-01T10:00:00Z"}
+	if got != want {
+		t.Fatalf("fill() = %#v, want %#v", got, want)
+	}
+	if s := got.String(); s != "1.2.3 (0123456 2024-05-01T10:00:00Z)" {
+		t.Fatalf("String() = %q", s)
+	}
+
+	stamped := Info{Version: "2.0.0", Commit: "fedcba"}
+	got = fill(stamped, &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}, Settings: bi.Settings})
+	want = Info{Version: "2.0.0", Commit: "fedcba", Date: "2024-05-01T10:00:00Z"}
+	if got != want {
+		t.Fatalf("fill() = %#v, want %#v", got, want)
+	}
+
+	if s := (Info{}).String(); s != "unknown" {
+		t.Fatalf("String() = %q, want unknown", s)
+	}
+}
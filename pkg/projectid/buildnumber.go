@@ -0,0 +1,82 @@
+package projectid
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elsejj/verit/pkg/version"
+)
+
+// nowFunc is the clock of the `timestamp` build number policy
+var nowFunc = time.Now
+
+// nextBuildNumber returns the build number of v, like the Android versionCode or the `+N` of
+// a Flutter version, replacing current, -1 if there is none. A numeric build of v sets it
+// explicitly, otherwise it follows the Options.VersionCode policy:
+//   - "increment" (default): current + 1
+//   - "git": the number of commits of HEAD in workdir
+//   - "timestamp": the current Unix time in seconds
+//   - a formula of the version numbers, like "major*10000+minor*100+patch"
+func nextBuildNumber(opts *Options, workdir string, v *version.Version, current int) (int, error) {
+	if v.Build != "" {
+		n, err := strconv.Atoi(v.Build)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("build %q should be a non negative integer", v.Build)
+		}
+		return n, nil
+	}
+	policy := ""
+	if opts != nil {
+		policy = opts.VersionCode
+	}
+	switch policy {
+	case "", "increment":
+		return current + 1, nil
+	case "git":
+		return gitCommitCount(workdir)
+	case "timestamp":
+		return int(nowFunc().Unix()), nil
+	}
+	return evalVersionFormula(policy, v)
+}
+
+// gitCommitCount returns the number of commits reachable from HEAD
+func gitCommitCount(workdir string) (int, error) {
+	cmd := exec.Command("git", "rev-list", "--count", "HEAD")
+	cmd.Dir = workdir
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("count git commits failed: %w", err)
+	}
+	return strconv.Atoi(strings.TrimSpace(string(out)))
+}
+
+// evalVersionFormula evaluates a sum of products of integers and `major`, `minor`, `patch`
+func evalVersionFormula(formula string, v *version.Version) (int, error) {
+	sum := 0
+	for _, term := range strings.Split(formula, "+") {
+		product := 1
+		for _, factor := range strings.Split(term, "*") {
+			factor = strings.TrimSpace(factor)
+			switch factor {
+			case "major":
+				product *= v.Major
+			case "minor":
+				product *= v.Minor
+			case "patch":
+				product *= v.Patch
+			default:
+				n, err := strconv.Atoi(factor)
+				if err != nil {
+					return 0, fmt.Errorf("invalid build number formula %q: unknown factor %q", formula, factor)
+				}
+				product *= n
+			}
+		}
+		sum += product
+	}
+	return sum, nil
+}
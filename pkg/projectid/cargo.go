@@ -0,0 +1,232 @@
+package projectid
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/elsejj/verit/internal/toml"
+	"github.com/elsejj/verit/internal/utils"
+)
+
+// cargoManifest is a parsed Cargo.toml
+type cargoManifest struct {
+	file string
+	doc  *toml.Document
+}
+
+func loadCargoManifest(dir string) (*cargoManifest, error) {
+	fileName := path.Join(dir, "Cargo.toml")
+	doc, err := toml.Load(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return &cargoManifest{file: fileName, doc: doc}, nil
+}
+
+func (m *cargoManifest) dir() string {
+	return path.Dir(m.file)
+}
+
+func (m *cargoManifest) packageName() string {
+	name, _ := m.doc.GetString("package", "name")
+	return name
+}
+
+func (m *cargoManifest) isWorkspace() bool {
+	return m.doc.HasTable("workspace")
+}
+
+// inheritsVersion reports whether the package uses `version.workspace = true`
+func (m *cargoManifest) inheritsVersion() bool {
+	v := m.doc.Get("package", "version", "workspace")
+	return v != nil && v.Kind == toml.Bool && v.Text == "true"
+}
+
+func (m *cargoManifest) stringArray(path ...string) []string {
+	var items []string
+	if v := m.doc.Get(path...); v != nil {
+		for _, item := range v.Items {
+			if item.Kind == toml.String {
+				items = append(items, item.Text)
+			}
+		}
+	}
+	return items
+}
+
+// workspaceMembers returns the manifests of the workspace members, including the
+// root package if any. Glob patterns in `members` and `exclude` are expanded.
+func (m *cargoManifest) workspaceMembers() ([]*cargoManifest, error) {
+	root := m.dir()
+	var excluded []string
+	for _, pattern := range m.stringArray("workspace", "exclude") {
+		matches, err := filepath.Glob(path.Join(root, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace exclude %q: %w", pattern, err)
+		}
+		excluded = append(excluded, matches...)
+	}
+
+	var members []*cargoManifest
+	if m.doc.HasTable("package") {
+		members = append(members, m)
+	}
+	for _, pattern := range m.stringArray("workspace", "members") {
+		matches, err := filepath.Glob(path.Join(root, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace member %q: %w", pattern, err)
+		}
+		for _, dir := range matches {
+			dir = path.Clean(dir)
+			if dir == root || slices.Contains(excluded, dir) || !utils.FileExists(dir, "Cargo.toml") {
+				continue
+			}
+			member, err := loadCargoManifest(dir)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// findCargoWorkspace looks for the workspace root manifest in dir and its parents.
+func findCargoWorkspace(dir string) (*cargoManifest, error) {
+	dir = path.Clean(dir)
+	for {
+		if utils.FileExists(dir, "Cargo.toml") {
+			m, err := loadCargoManifest(dir)
+			if err != nil {
+				return nil, err
+			}
+			if m.isWorkspace() {
+				return m, nil
+			}
+		}
+		parent := path.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("workspace root not found")
+		}
+		dir = parent
+	}
+}
+
+// cargoUpdate is the version change of a local crate
+type cargoUpdate struct {
+	name string
+	from string
+	to   string
+}
+
+// updateCargoLock rewrites the version of local crates in the Cargo.lock found
+// in workdir or its parents, so `cargo build --locked` keeps working.
+// Only source-less entries are touched, registry and git crates may share a name.
+func updateCargoLock(workdir string, updates []cargoUpdate) error {
+	fileName, ok := utils.FindFileUp(workdir, "Cargo.lock")
+	if !ok || len(updates) == 0 {
+		return nil
+	}
+	doc, err := toml.Load(fileName)
+	if err != nil {
+		return err
+	}
+
+	var edits []toml.Edit
+	for _, pkg := range doc.ArrayTable("package") {
+		var name, source, ver *toml.Value
+		var deps []*toml.Value
+		for _, e := range pkg {
+			switch e.Path[len(e.Path)-1] {
+			case "name":
+				name = e.Value
+			case "source":
+				source = e.Value
+			case "version":
+				ver = e.Value
+			case "dependencies":
+				deps = e.Value.Items
+			}
+		}
+		if name != nil && source == nil && ver != nil {
+			for _, u := range updates {
+				if name.Text == u.name && ver.Text != u.to {
+					edits = append(edits, toml.Edit{Value: ver, Raw: ver.Quote(u.to)})
+				}
+			}
+		}
+		// dependencies are written as "name version" when several versions of a crate are locked
+		for _, dep := range deps {
+			for _, u := range updates {
+				if dep.Text == u.name+" "+u.from && u.from != u.to {
+					edits = append(edits, toml.Edit{Value: dep, Raw: dep.Quote(u.name + " " + u.to)})
+				}
+			}
+		}
+	}
+	if len(edits) == 0 {
+		return nil
+	}
+
+	if err := doc.Apply(edits...); err != nil {
+		return fmt.Errorf("update %s failed: %w", fileName, err)
+	}
+	return doc.Save(fileName)
+}
+
+// cargoDependencyTables are the tables declaring dependencies, at any depth
+// (e.g. [target.'cfg(unix)'.dependencies] or [workspace.dependencies])
+var cargoDependencyTables = []string{"dependencies", "dev-dependencies", "build-dependencies"}
+
+// updateCargoDependents rewrites the version requirement of path dependencies on
+// the updated crates, in every member of the workspace and in [workspace.dependencies].
+func updateCargoDependents(ws *cargoManifest, updates []cargoUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	members, err := ws.workspaceMembers()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(members, ws) {
+		members = append(members, ws)
+	}
+
+	for _, m := range members {
+		var edits []toml.Edit
+		for _, e := range m.doc.Entries() {
+			n := len(e.Path)
+			if n < 3 || e.Path[n-1] != "version" || !slices.Contains(cargoDependencyTables, e.Path[n-3]) || e.Value.Kind != toml.String {
+				continue
+			}
+			dep := e.Path[:n-1]
+			if m.doc.Get(append(slices.Clone(dep), "path")...) == nil {
+				continue
+			}
+			name := dep[len(dep)-1]
+			if pkg, ok := m.doc.GetString(append(slices.Clone(dep), "package")...); ok {
+				name = pkg
+			}
+			for _, u := range updates {
+				if u.name != name {
+					continue
+				}
+				if req := bumpRequirement(e.Value.Text, u.to); req != e.Value.Text {
+					edits = append(edits, toml.Edit{Value: e.Value, Raw: e.Value.Quote(req)})
+				}
+			}
+		}
+		if len(edits) == 0 {
+			continue
+		}
+		if err := m.doc.Apply(edits...); err != nil {
+			return fmt.Errorf("update %s failed: %w", m.file, err)
+		}
+		if err := m.doc.Save(m.file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
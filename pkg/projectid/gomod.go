@@ -0,0 +1,256 @@
+package projectid
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/elsejj/verit/internal/utils"
+)
+
+var goModuleRE = regexp.MustCompile(`(?m)^module[ \t]+"?([^\s"]+)"?`)
+var goMajorSuffixRE = regexp.MustCompile(`/v([2-9]|[1-9]\d+)$`)
+
+// goMajorPath returns the path of module modulePath for the major version major:
+// no suffix for v0 and v1, a `/vN` suffix from v2.
+func goMajorPath(modulePath string, major int) (string, error) {
+	if strings.HasPrefix(modulePath, "gopkg.in/") {
+		return "", fmt.Errorf("major version of gopkg.in module %s is in its .vN suffix, it is not supported", modulePath)
+	}
+	base := goMajorSuffixRE.ReplaceAllString(modulePath, "")
+	if major < 2 {
+		return base, nil
+	}
+	return fmt.Sprintf("%s/v%d", base, major), nil
+}
+
+// goSourceFiles returns the .go files of the module in dir, skipping vendor, testdata,
+// hidden directories and nested modules.
+func goSourceFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if file != dir && (name == "vendor" || name == "testdata" ||
+				strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+				utils.FileExists(path.Join(file, "go.mod"))) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(file, ".go") {
+			files = append(files, file)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// rewriteGoImports replaces the import paths of fileName under module from by module to.
+func rewriteGoImports(fileName, from, to string) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, fileName, data, parser.ImportsOnly)
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	last := 0
+	for _, imp := range f.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || (p != from && !strings.HasPrefix(p, from+"/")) {
+			continue
+		}
+		start := fset.Position(imp.Path.Pos()).Offset
+		end := fset.Position(imp.Path.End()).Offset
+		out = append(out, data[last:start]...)
+		out = append(out, strconv.Quote(to+p[len(from):])...)
+		last = end
+	}
+	if last == 0 {
+		return nil
+	}
+	out = append(out, data[last:]...)
+	stat, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, out, stat.Mode())
+}
+
+// isGoPathByte reports whether c may be part of an import path
+func isGoPathByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-._~", c) >= 0
+}
+
+// goModuleReferences returns the `file:line` places of fileName still referring to module
+// from, which has moved to module to.
+func goModuleReferences(fileName, from, to string) ([]string, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var refs []string
+	for offset := 0; ; {
+		i := bytes.Index(data[offset:], []byte(from))
+		if i < 0 {
+			break
+		}
+		i += offset
+		offset = i + len(from)
+		if i > 0 && isGoPathByte(data[i-1]) {
+			continue
+		}
+		if offset < len(data) && isGoPathByte(data[offset]) {
+			continue
+		}
+		if len(to) > len(from) && bytes.HasPrefix(data[i:], []byte(to)) {
+			// the new path, when it extends the old one
+			continue
+		}
+		ref := fmt.Sprintf("%s:%d", fileName, bytes.Count(data[:i], []byte("\n"))+1)
+		if !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// migrateGoMajor moves the module in dir to the path of the major version major, rewriting
+// the module line of go.mod and the imports of its .go files. It returns the places still
+// referring to the previous module path.
+func migrateGoMajor(dir string, major int) ([]string, error) {
+	goMod := path.Join(dir, "go.mod")
+	from, err := utils.Grep(goMod, goModuleRE)
+	if err != nil {
+		return nil, fmt.Errorf("module path not found in %s", goMod)
+	}
+	to, err := goMajorPath(from, major)
+	if err != nil || to == from {
+		return nil, err
+	}
+
+	if err := utils.Sed(goMod, goModuleRE, to); err != nil {
+		return nil, err
+	}
+	files, err := goSourceFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if err := rewriteGoImports(file, from, to); err != nil {
+			return nil, fmt.Errorf("rewrite imports of %s failed: %w", file, err)
+		}
+	}
+
+	var refs []string
+	for _, file := range append([]string{goMod}, files...) {
+		found, err := goModuleReferences(file, from, to)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, found...)
+	}
+	return refs, nil
+}
+
+// goVersionLiteral is the string literal assigned to a version constant or variable
+type goVersionLiteral struct {
+	file  string
+	value string
+	// start and end are the byte offsets of the quoted literal
+	start int
+	end   int
+}
+
+// findGoVersionLiteral returns the top-level string constant or variable named name
+// in the non test .go files of the module in dir, or nil.
+func findGoVersionLiteral(dir, name string) (*goVersionLiteral, error) {
+	files, err := goSourceFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var found []*goVersionLiteral
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Contains(data, []byte(name)) {
+			continue
+		}
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, file, data, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, ident := range vs.Names {
+					if ident.Name != name || i >= len(vs.Values) {
+						continue
+					}
+					lit, ok := vs.Values[i].(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						return nil, fmt.Errorf("%s in %s is not a string literal", name, file)
+					}
+					value, err := strconv.Unquote(lit.Value)
+					if err != nil {
+						return nil, err
+					}
+					found = append(found, &goVersionLiteral{
+						file:  file,
+						value: value,
+						start: fset.Position(lit.Pos()).Offset,
+						end:   fset.Position(lit.End()).Offset,
+					})
+				}
+			}
+		}
+	}
+	if len(found) > 1 {
+		return nil, fmt.Errorf("%s is defined in both %s and %s", name, found[0].file, found[1].file)
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	return found[0], nil
+}
+
+// set writes value to the literal, keeping its quotes
+func (l *goVersionLiteral) set(value string) error {
+	data, err := os.ReadFile(l.file)
+	if err != nil {
+		return err
+	}
+	raw := strconv.Quote(value)
+	if data[l.start] == '`' {
+		raw = "`" + value + "`"
+	}
+	return spliceFile(l.file, data, l.start, l.end, raw)
+}
@@ -9,6 +9,22 @@ const (
 	Node
 	Flutter
 	Rust
+	Maven
+	Gradle
+	Android
+	DotNet
+	Composer
+	Ruby
+	Elixir
+	Xcode
+	Helm
+	CMake
+	Meson
+	Autotools
+	Deno
+	Zig
+	Nim
+	Crystal
 	MaxProjectID
 )
 
@@ -28,6 +44,38 @@ func (p ProjectID) String() string {
 		return "Flutter"
 	case Rust:
 		return "Rust"
+	case Maven:
+		return "Maven"
+	case Gradle:
+		return "Gradle"
+	case Android:
+		return "Android"
+	case DotNet:
+		return "DotNet"
+	case Composer:
+		return "Composer"
+	case Ruby:
+		return "Ruby"
+	case Elixir:
+		return "Elixir"
+	case Xcode:
+		return "Xcode"
+	case Helm:
+		return "Helm"
+	case CMake:
+		return "CMake"
+	case Meson:
+		return "Meson"
+	case Autotools:
+		return "Autotools"
+	case Deno:
+		return "Deno"
+	case Zig:
+		return "Zig"
+	case Nim:
+		return "Nim"
+	case Crystal:
+		return "Crystal"
 	default:
 		return "Unknown"
 	}
@@ -47,16 +95,57 @@ func ParseProjectID(s string) ProjectID {
 		return Flutter
 	case "rust":
 		return Rust
+	case "maven":
+		return Maven
+	case "gradle":
+		return Gradle
+	case "android":
+		return Android
+	case "dotnet":
+		return DotNet
+	case "composer":
+		return Composer
+	case "ruby":
+		return Ruby
+	case "elixir":
+		return Elixir
+	case "xcode":
+		return Xcode
+	case "helm":
+		return Helm
+	case "cmake":
+		return CMake
+	case "meson":
+		return Meson
+	case "autotools":
+		return Autotools
+	case "deno":
+		return Deno
+	case "zig":
+		return Zig
+	case "nim":
+		return Nim
+	case "crystal":
+		return Crystal
 	default:
 		return 0
 	}
 }
 
 func (p ProjectID) Project(workdir string) Project {
+	return p.ProjectWith(workdir, nil)
+}
+
+// ProjectWith creates the project in workdir tuned by opts, nil opts keeps the defaults
+func (p ProjectID) ProjectWith(workdir string, opts *Options) Project {
+	if opts == nil {
+		opts = &Options{}
+	}
 	switch p {
 	case Mix:
 		m := &MixProject{
 			workdir: workdir,
+			opts:    opts,
 		}
 		m.scanProjects()
 		return m
@@ -67,6 +156,7 @@ func (p ProjectID) Project(workdir string) Project {
 	case Go:
 		return &GoProject{
 			workdir: workdir,
+			opts:    opts,
 		}
 	case Node:
 		return &NodeProject{
@@ -75,10 +165,81 @@ func (p ProjectID) Project(workdir string) Project {
 	case Flutter:
 		return &FlutterProject{
 			workdir: workdir,
+			opts:    opts,
 		}
 	case Rust:
 		return &RustProject{
 			workdir: workdir,
+			opts:    opts,
+		}
+	case Maven:
+		return &MavenProject{
+			workdir: workdir,
+		}
+	case Gradle:
+		return &GradleProject{
+			workdir: workdir,
+		}
+	case Android:
+		return &AndroidProject{
+			workdir: workdir,
+			opts:    opts,
+		}
+	case DotNet:
+		return &DotNetProject{
+			workdir: workdir,
+		}
+	case Composer:
+		return &ComposerProject{
+			workdir: workdir,
+			opts:    opts,
+		}
+	case Ruby:
+		return &RubyProject{
+			workdir: workdir,
+		}
+	case Elixir:
+		return &ElixirProject{
+			workdir: workdir,
+		}
+	case Xcode:
+		return &XcodeProject{
+			workdir: workdir,
+			opts:    opts,
+		}
+	case Helm:
+		return &HelmProject{
+			workdir: workdir,
+			opts:    opts,
+		}
+	case CMake:
+		return &CMakeProject{
+			workdir: workdir,
+		}
+	case Meson:
+		return &MesonProject{
+			workdir: workdir,
+		}
+	case Autotools:
+		return &AutotoolsProject{
+			workdir: workdir,
+		}
+	case Deno:
+		return &DenoProject{
+			workdir: workdir,
+			opts:    opts,
+		}
+	case Zig:
+		return &ZigProject{
+			workdir: workdir,
+		}
+	case Nim:
+		return &NimProject{
+			workdir: workdir,
+		}
+	case Crystal:
+		return &CrystalProject{
+			workdir: workdir,
 		}
 	default:
 		return nil
@@ -0,0 +1,144 @@
+package projectid
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/elsejj/verit/internal/json"
+	"github.com/elsejj/verit/internal/toml"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+// findTOMLString returns the first string defined at one of the key paths.
+func findTOMLString(doc *toml.Document, keys [][]string) (string, bool) {
+	for _, key := range keys {
+		if v, ok := doc.GetString(key...); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// setTOMLString rewrites the first string defined at one of the key paths in fileName.
+func setTOMLString(fileName string, keys [][]string, value string) error {
+	doc, err := toml.Load(fileName)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, ok := doc.GetString(key...); !ok {
+			continue
+		}
+		if err := doc.SetString(value, key...); err != nil {
+			return err
+		}
+		return doc.Save(fileName)
+	}
+	return fmt.Errorf("version not found in %s", fileName)
+}
+
+// setJSONString rewrites the string at the object key path in fileName.
+func setJSONString(fileName string, value string, path ...string) error {
+	doc, err := json.Load(fileName)
+	if err != nil {
+		return err
+	}
+	if _, ok := doc.GetString(path...); !ok {
+		return fmt.Errorf("%s not found in %s", strings.Join(path, "."), fileName)
+	}
+	if err := doc.SetString(value, path...); err != nil {
+		return err
+	}
+	return doc.Save(fileName)
+}
+
+// bumpRequirement returns the version requirement req pointing at version to, keeping its operator
+func bumpRequirement(req, to string) string {
+	if strings.Contains(req, ",") {
+		return to
+	}
+	i := strings.IndexFunc(req, unicode.IsDigit)
+	if i < 0 {
+		return to
+	}
+	return req[:i] + to
+}
+
+// findCallArgument returns the byte offsets of the first capture group of argRE in the
+// arguments of the first call matched by callRE, which should end with the opening parenthesis.
+func findCallArgument(data []byte, callRE, argRE *regexp.Regexp) (int, int, bool) {
+	loc := callRE.FindIndex(data)
+	if loc == nil {
+		return 0, 0, false
+	}
+	depth := 1
+	end := loc[1]
+	for ; end < len(data) && depth > 0; end++ {
+		switch data[end] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+	}
+	m := argRE.FindSubmatchIndex(data[loc[1]:end])
+	if m == nil || m[2] < 0 {
+		return 0, 0, false
+	}
+	return loc[1] + m[2], loc[1] + m[3], true
+}
+
+// spliceFile writes data to fileName with the bytes from start to end replaced by value.
+func spliceFile(fileName string, data []byte, start, end int, value string) error {
+	stat, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+	out := make([]byte, 0, len(data)-(end-start)+len(value))
+	out = append(out, data[:start]...)
+	out = append(out, value...)
+	out = append(out, data[end:]...)
+	return os.WriteFile(fileName, out, stat.Mode())
+}
+
+// fileEdit replaces the bytes from start to end of a file by raw
+type fileEdit struct {
+	start int
+	end   int
+	raw   string
+}
+
+// applyFileEdits writes data to fileName with the non overlapping edits applied at once
+func applyFileEdits(fileName string, data []byte, edits []fileEdit) error {
+	slices.SortFunc(edits, func(a, b fileEdit) int {
+		return a.start - b.start
+	})
+	var out []byte
+	last := 0
+	for _, e := range edits {
+		out = append(out, data[last:e.start]...)
+		out = append(out, e.raw...)
+		last = e.end
+	}
+	out = append(out, data[last:]...)
+	stat, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, out, stat.Mode())
+}
+
+var shortVersionRE = regexp.MustCompile(`^(\d+(?:\.\d+)?)([-+].*)?$`)
+
+// parseShortVersion parses s as a version, accepting the `1` and `1.2` forms of `1.0.0` and `1.2.0`,
+// with an optional prerelease or build, like the `1.0-SNAPSHOT` of Maven.
+func parseShortVersion(s string) (*version.Version, error) {
+	if m := shortVersionRE.FindStringSubmatch(s); m != nil {
+		s = m[1] + strings.Repeat(".0", 2-strings.Count(m[1], ".")) + m[2]
+	}
+	return version.Parse(s)
+}
@@ -0,0 +1,137 @@
+package projectid
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+AndroidProject represents an Android app, the version is kept in two fields:
+  - `versionName` is the semantic version, a short `1.0` reads as `1.0.0`
+  - `versionCode` is an integer that must increase on every release, it is the build of the version, like `1.2.3+42`
+
+they are looked up in build.gradle(.kts) or AndroidManifest.xml of the project or its `app` module.
+on every version change, versionCode follows the Options.VersionCode policy: increased by 1,
+the git commit count, a timestamp or a formula of the version, and it is never decreased.
+a numeric build in the version sets versionCode explicitly.
+*/
+type AndroidProject struct {
+	workdir string
+	opts    *Options
+}
+
+type androidVersionFile struct {
+	name   string
+	nameRE *regexp.Regexp
+	codeRE *regexp.Regexp
+}
+
+var androidGradleNameRE = regexp.MustCompile(`(?m)^[ \t]*versionName[ \t]*=?[ \t]*["']([^"'$]+)["']`)
+var androidGradleCodeRE = regexp.MustCompile(`(?m)^[ \t]*versionCode[ \t]*=?[ \t]*(\d+)`)
+var androidManifestNameRE = regexp.MustCompile(`android:versionName\s*=\s*"([^"]+)"`)
+var androidManifestCodeRE = regexp.MustCompile(`android:versionCode\s*=\s*"(\d+)"`)
+
+var androidVersionFiles = []androidVersionFile{
+	{"build.gradle.kts", androidGradleNameRE, androidGradleCodeRE},
+	{"build.gradle", androidGradleNameRE, androidGradleCodeRE},
+	{"app/build.gradle.kts", androidGradleNameRE, androidGradleCodeRE},
+	{"app/build.gradle", androidGradleNameRE, androidGradleCodeRE},
+	{"AndroidManifest.xml", androidManifestNameRE, androidManifestCodeRE},
+	{"src/main/AndroidManifest.xml", androidManifestNameRE, androidManifestCodeRE},
+	{"app/src/main/AndroidManifest.xml", androidManifestNameRE, androidManifestCodeRE},
+}
+
+// findAndroidVersionFile returns the first file of workdir defining versionName
+func findAndroidVersionFile(workdir string) (string, *androidVersionFile) {
+	for i, f := range androidVersionFiles {
+		fileName := path.Join(workdir, f.name)
+		if !utils.FileExists(fileName) {
+			continue
+		}
+		if _, err := utils.Grep(fileName, f.nameRE); err == nil {
+			return fileName, &androidVersionFiles[i]
+		}
+	}
+	return "", nil
+}
+
+func isAndroid(workdir string) bool {
+	fileName, _ := findAndroidVersionFile(workdir)
+	return fileName != ""
+}
+
+func (p *AndroidProject) IsMe(workdir string) bool {
+	return isAndroid(workdir)
+}
+
+func (p *AndroidProject) ID() ProjectID {
+	return Android
+}
+
+func (p *AndroidProject) WorkDir() string {
+	return p.workdir
+}
+
+func (p *AndroidProject) versionFile() (string, *androidVersionFile, error) {
+	fileName, f := findAndroidVersionFile(p.workdir)
+	if f == nil {
+		return "", nil, fmt.Errorf("versionName not found in build.gradle(.kts) or AndroidManifest.xml")
+	}
+	return fileName, f, nil
+}
+
+func (p *AndroidProject) GetVersion() (*version.Version, error) {
+	fileName, f, err := p.versionFile()
+	if err != nil {
+		return nil, err
+	}
+	name, err := utils.Grep(fileName, f.nameRE)
+	if err != nil {
+		return nil, fmt.Errorf("versionName not found")
+	}
+	v, err := parseShortVersion(name)
+	if err != nil {
+		return nil, err
+	}
+	if code, err := utils.Grep(fileName, f.codeRE); err == nil {
+		v.Build = code
+	}
+	return v, nil
+}
+
+func (p *AndroidProject) SetVersion(v *version.Version) error {
+	fileName, f, err := p.versionFile()
+	if err != nil {
+		return err
+	}
+
+	current := -1
+	if code, err := utils.Grep(fileName, f.codeRE); err == nil {
+		current, _ = strconv.Atoi(code)
+	}
+	code, err := nextBuildNumber(p.opts, p.workdir, v, current)
+	if err != nil {
+		return err
+	}
+	if code < current {
+		return fmt.Errorf("versionCode can not decrease from %d to %d", current, code)
+	}
+
+	name := *v
+	name.Build = ""
+	if err := utils.Sed(fileName, f.nameRE, name.String()); err != nil {
+		return err
+	}
+	if current < 0 {
+		// nothing to update, the app has no versionCode yet
+		return nil
+	}
+	return utils.Sed(fileName, f.codeRE, strconv.Itoa(code))
+}
+
+var _ Project = &AndroidProject{}
@@ -0,0 +1,72 @@
+package projectid
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+AutotoolsProject represents a project built by Autotools, the version is the second
+argument of `AC_INIT` in configure.ac, like `AC_INIT([x], [1.2.3])`.
+
+a version computed by a macro, like `m4_esyscmd(...)`, is not supported.
+*/
+type AutotoolsProject struct {
+	workdir string
+}
+
+func (p *AutotoolsProject) versionFile() string {
+	return path.Join(p.workdir, "configure.ac")
+}
+
+func isAutotools(workdir string) bool {
+	return utils.FileExists(path.Join(workdir, "configure.ac"))
+}
+
+func (p *AutotoolsProject) IsMe(workdir string) bool {
+	return isAutotools(workdir)
+}
+
+func (p *AutotoolsProject) ID() ProjectID {
+	return Autotools
+}
+
+func (p *AutotoolsProject) WorkDir() string {
+	return p.workdir
+}
+
+var autotoolsInitRE = regexp.MustCompile(`(?m)^[ \t]*AC_INIT[ \t]*\(`)
+
+// the package name, quoted or not, then the version, quoted or not
+var autotoolsVersionRE = regexp.MustCompile(`^\s*(?:\[[^\]]*\]|[^,\[]*),\s*\[?\s*([0-9][^\s\],)]*)`)
+
+func (p *AutotoolsProject) GetVersion() (*version.Version, error) {
+	data, err := os.ReadFile(p.versionFile())
+	if err != nil {
+		return nil, err
+	}
+	start, end, ok := findCallArgument(data, autotoolsInitRE, autotoolsVersionRE)
+	if !ok {
+		return nil, fmt.Errorf("version not found in AC_INIT")
+	}
+	return parseShortVersion(string(data[start:end]))
+}
+
+func (p *AutotoolsProject) SetVersion(v *version.Version) error {
+	data, err := os.ReadFile(p.versionFile())
+	if err != nil {
+		return err
+	}
+	start, end, ok := findCallArgument(data, autotoolsInitRE, autotoolsVersionRE)
+	if !ok {
+		return fmt.Errorf("version not found in AC_INIT")
+	}
+	return spliceFile(p.versionFile(), data, start, end, v.String())
+}
+
+var _ Project = &AutotoolsProject{}
@@ -0,0 +1,87 @@
+package projectid
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+CMakeProject represents a C/C++ project built by CMake, the version is the `VERSION`
+argument of the top-level `project()` call in CMakeLists.txt.
+
+CMake versions are numeric only, `major[.minor[.patch[.tweak]]]`, the tweak is not supported.
+*/
+type CMakeProject struct {
+	workdir string
+}
+
+func (p *CMakeProject) versionFile() string {
+	return path.Join(p.workdir, "CMakeLists.txt")
+}
+
+func isCMake(workdir string) bool {
+	return utils.FileExists(path.Join(workdir, "CMakeLists.txt"))
+}
+
+func (p *CMakeProject) IsMe(workdir string) bool {
+	return isCMake(workdir)
+}
+
+func (p *CMakeProject) ID() ProjectID {
+	return CMake
+}
+
+func (p *CMakeProject) WorkDir() string {
+	return p.workdir
+}
+
+var cmakeProjectRE = regexp.MustCompile(`(?mi)^[ \t]*project[ \t]*\(`)
+var cmakeVersionRE = regexp.MustCompile(`(?:^|\s)VERSION\s+"?([0-9][0-9.]*)"?`)
+var cmakeNumericRE = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+
+func (p *CMakeProject) GetVersion() (*version.Version, error) {
+	data, err := os.ReadFile(p.versionFile())
+	if err != nil {
+		return nil, err
+	}
+	start, end, ok := findCallArgument(data, cmakeProjectRE, cmakeVersionRE)
+	if !ok {
+		return nil, fmt.Errorf("version not found in project()")
+	}
+	s := string(data[start:end])
+	if strings.Count(s, ".") == 3 {
+		return nil, fmt.Errorf("cmake version %s has a tweak component, it is not supported", s)
+	}
+	if !cmakeNumericRE.MatchString(s) {
+		return nil, fmt.Errorf("invalid cmake version: %s", s)
+	}
+	return parseShortVersion(s)
+}
+
+func (p *CMakeProject) SetVersion(v *version.Version) error {
+	if v.Prerelease != "" {
+		return fmt.Errorf("cmake versions are numeric only, prerelease %s is not supported", v.Prerelease)
+	}
+	if v.Build != "" {
+		return fmt.Errorf("cmake versions are numeric only, build %s is not supported", v.Build)
+	}
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	data, err := os.ReadFile(p.versionFile())
+	if err != nil {
+		return err
+	}
+	start, end, ok := findCallArgument(data, cmakeProjectRE, cmakeVersionRE)
+	if !ok {
+		return fmt.Errorf("version not found in project()")
+	}
+	return spliceFile(p.versionFile(), data, start, end, s)
+}
+
+var _ Project = &CMakeProject{}
@@ -0,0 +1,75 @@
+package projectid
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/elsejj/verit/internal/json"
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+ComposerProject represents a PHP project, the version is the top-level `version` field of composer.json.
+
+composer recommends to omit the field and let it be inferred from VCS tags, so it is only
+added, after `name`, when the version is set explicitly (Options.CreateVersion).
+*/
+type ComposerProject struct {
+	workdir string
+	opts    *Options
+}
+
+func (p *ComposerProject) versionFile() string {
+	return path.Join(p.workdir, "composer.json")
+}
+
+func isComposer(workdir string) bool {
+	return utils.FileExists(path.Join(workdir, "composer.json"))
+}
+
+func (p *ComposerProject) IsMe(workdir string) bool {
+	return isComposer(workdir)
+}
+
+func (p *ComposerProject) ID() ProjectID {
+	return Composer
+}
+
+func (p *ComposerProject) WorkDir() string {
+	return p.workdir
+}
+
+func (p *ComposerProject) GetVersion() (*version.Version, error) {
+	doc, err := json.Load(p.versionFile())
+	if err != nil {
+		return nil, err
+	}
+	v, ok := doc.GetString("version")
+	if !ok {
+		return nil, fmt.Errorf("version not found")
+	}
+
+	return version.Parse(v)
+}
+
+func (p *ComposerProject) SetVersion(v *version.Version) error {
+	fileName := p.versionFile()
+	doc, err := json.Load(fileName)
+	if err != nil {
+		return err
+	}
+	if doc.Get("version") != nil {
+		err = doc.SetString(v.String(), "version")
+	} else if p.opts != nil && p.opts.CreateVersion {
+		err = doc.InsertString("version", v.String(), "name")
+	} else {
+		return fmt.Errorf("version not found in %s, set it explicitly with --version to add it", fileName)
+	}
+	if err != nil {
+		return err
+	}
+	return doc.Save(fileName)
+}
+
+var _ Project = &ComposerProject{}
@@ -0,0 +1,55 @@
+package projectid
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+CrystalProject represents a Crystal shard, the version is the top-level `version` field of shard.yml,
+the indented `version` of dependencies is never used.
+*/
+type CrystalProject struct {
+	workdir string
+}
+
+func (p *CrystalProject) versionFile() string {
+	return path.Join(p.workdir, "shard.yml")
+}
+
+func isCrystal(workdir string) bool {
+	return utils.FileExists(path.Join(workdir, "shard.yml"))
+}
+
+func (p *CrystalProject) IsMe(workdir string) bool {
+	return isCrystal(workdir)
+}
+
+func (p *CrystalProject) ID() ProjectID {
+	return Crystal
+}
+
+func (p *CrystalProject) WorkDir() string {
+	return p.workdir
+}
+
+var shardVersionRE = regexp.MustCompile(`(?m)^version:[ \t]*["']?([^\s"'#]+)["']?`)
+
+func (p *CrystalProject) GetVersion() (*version.Version, error) {
+	v, err := utils.Grep(p.versionFile(), shardVersionRE)
+	if err != nil {
+		return nil, fmt.Errorf("version not found")
+	}
+
+	return version.Parse(v)
+}
+
+func (p *CrystalProject) SetVersion(v *version.Version) error {
+	return utils.Sed(p.versionFile(), shardVersionRE, v.String())
+}
+
+var _ Project = &CrystalProject{}
@@ -0,0 +1,120 @@
+package projectid
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/elsejj/verit/internal/json"
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+DenoProject represents a Deno or JSR package, the version is the top-level `version` field of
+deno.json, deno.jsonc or jsr.json, all read as JSONC. the manifests defining it must agree,
+and they are all updated.
+
+when none defines it, the field is only added, after `name` of the first manifest, when the
+version is set explicitly (Options.CreateVersion).
+*/
+type DenoProject struct {
+	workdir string
+	opts    *Options
+}
+
+var denoManifests = []string{"deno.json", "deno.jsonc", "jsr.json"}
+
+// manifests returns the existing manifests of the project
+func (p *DenoProject) manifests() []string {
+	var files []string
+	for _, name := range denoManifests {
+		if fileName := path.Join(p.workdir, name); utils.FileExists(fileName) {
+			files = append(files, fileName)
+		}
+	}
+	return files
+}
+
+func isDeno(workdir string) bool {
+	for _, name := range denoManifests {
+		if utils.FileExists(path.Join(workdir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *DenoProject) IsMe(workdir string) bool {
+	return isDeno(workdir)
+}
+
+func (p *DenoProject) ID() ProjectID {
+	return Deno
+}
+
+func (p *DenoProject) WorkDir() string {
+	return p.workdir
+}
+
+func (p *DenoProject) GetVersion() (*version.Version, error) {
+	current, currentFile := "", ""
+	for _, fileName := range p.manifests() {
+		doc, err := json.LoadJSONC(fileName)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := doc.GetString("version")
+		if !ok {
+			continue
+		}
+		if current != "" && v != current {
+			return nil, fmt.Errorf("version mismatch between %s (%s) and %s (%s)", path.Base(currentFile), current, path.Base(fileName), v)
+		}
+		current, currentFile = v, fileName
+	}
+	if current == "" {
+		return nil, fmt.Errorf("version not found")
+	}
+
+	return version.Parse(current)
+}
+
+func (p *DenoProject) SetVersion(v *version.Version) error {
+	files := p.manifests()
+	updated := false
+	for _, fileName := range files {
+		doc, err := json.LoadJSONC(fileName)
+		if err != nil {
+			return err
+		}
+		if doc.Get("version") == nil {
+			continue
+		}
+		if err := doc.SetString(v.String(), "version"); err != nil {
+			return err
+		}
+		if err := doc.Save(fileName); err != nil {
+			return err
+		}
+		updated = true
+	}
+	if updated {
+		return nil
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("deno.json or jsr.json not found")
+	}
+	if p.opts == nil || !p.opts.CreateVersion {
+		return fmt.Errorf("version not found in %s, set it explicitly with --version to add it", path.Base(files[0]))
+	}
+	doc, err := json.LoadJSONC(files[0])
+	if err != nil {
+		return err
+	}
+	if err := doc.InsertString("version", v.String(), "name"); err != nil {
+		return err
+	}
+	return doc.Save(files[0])
+}
+
+var _ Project = &DenoProject{}
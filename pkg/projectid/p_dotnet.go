@@ -0,0 +1,141 @@
+package projectid
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/internal/xml"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+DotNetProject represents a .NET project, the version is looked up in the `*.csproj` and `*.fsproj`
+of the project, then in the shared `Directory.Build.props` of the project or its parents:
+  - `<Version>1.2.3-rc.1</Version>`
+  - or `<VersionPrefix>1.2.3</VersionPrefix>` with an optional `<VersionSuffix>rc.1</VersionSuffix>`
+
+`<AssemblyVersion>` and `<FileVersion>` defined next to it are kept in sync, they are numeric only
+so the prerelease is dropped and a fourth (revision) component is kept as is.
+*/
+type DotNetProject struct {
+	workdir string
+}
+
+func dotnetProjectFiles(workdir string) []string {
+	entries, err := os.ReadDir(workdir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if !entry.IsDir() && (ext == ".csproj" || ext == ".fsproj") {
+			files = append(files, path.Join(workdir, entry.Name()))
+		}
+	}
+	slices.Sort(files)
+	return files
+}
+
+func isDotNet(workdir string) bool {
+	return len(dotnetProjectFiles(workdir)) > 0 || utils.FileExists(path.Join(workdir, "Directory.Build.props"))
+}
+
+func (p *DotNetProject) IsMe(workdir string) bool {
+	return isDotNet(workdir)
+}
+
+func (p *DotNetProject) ID() ProjectID {
+	return DotNet
+}
+
+func (p *DotNetProject) WorkDir() string {
+	return p.workdir
+}
+
+func dotnetProperty(doc *xml.Document, name string) *xml.Element {
+	return doc.First("Project", "PropertyGroup", name)
+}
+
+// versionFile returns the first project file, or Directory.Build.props, defining the version
+func (p *DotNetProject) versionFile() (string, *xml.Document, error) {
+	files := dotnetProjectFiles(p.workdir)
+	if props, ok := utils.FindFileUp(p.workdir, "Directory.Build.props"); ok {
+		files = append(files, props)
+	}
+	for _, fileName := range files {
+		doc, err := xml.Load(fileName)
+		if err != nil {
+			return "", nil, err
+		}
+		if dotnetProperty(doc, "Version") != nil || dotnetProperty(doc, "VersionPrefix") != nil {
+			return fileName, doc, nil
+		}
+	}
+	return "", nil, fmt.Errorf("<Version> or <VersionPrefix> not found in project files or Directory.Build.props")
+}
+
+func (p *DotNetProject) GetVersion() (*version.Version, error) {
+	_, doc, err := p.versionFile()
+	if err != nil {
+		return nil, err
+	}
+	if e := dotnetProperty(doc, "Version"); e != nil {
+		return version.Parse(e.Text)
+	}
+	s := dotnetProperty(doc, "VersionPrefix").Text
+	if suffix := dotnetProperty(doc, "VersionSuffix"); suffix != nil && suffix.Text != "" {
+		s += "-" + suffix.Text
+	}
+	return version.Parse(s)
+}
+
+func (p *DotNetProject) SetVersion(v *version.Version) error {
+	fileName, doc, err := p.versionFile()
+	if err != nil {
+		return err
+	}
+
+	var edits []xml.Edit
+	if e := dotnetProperty(doc, "Version"); e != nil {
+		edits = append(edits, xml.SetText(e, v.String()))
+	} else {
+		if v.Build != "" {
+			return fmt.Errorf("build metadata is not supported with <VersionPrefix>, use <Version> instead")
+		}
+		edits = append(edits, xml.SetText(dotnetProperty(doc, "VersionPrefix"), fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)))
+		suffix := dotnetProperty(doc, "VersionSuffix")
+		if suffix == nil && v.Prerelease != "" {
+			return fmt.Errorf("<VersionSuffix> not found in %s to set prerelease %s", fileName, v.Prerelease)
+		}
+		if suffix != nil {
+			edits = append(edits, xml.SetText(suffix, v.Prerelease))
+		}
+	}
+
+	for _, name := range []string{"AssemblyVersion", "FileVersion"} {
+		if e := dotnetProperty(doc, name); e != nil {
+			edits = append(edits, xml.SetText(e, dotnetNumericVersion(e.Text, v)))
+		}
+	}
+
+	if err := doc.Apply(edits...); err != nil {
+		return err
+	}
+	return doc.Save(fileName)
+}
+
+// dotnetNumericVersion returns v as a numeric assembly version, keeping the revision of old
+func dotnetNumericVersion(old string, v *version.Version) string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if parts := strings.Split(old, "."); len(parts) == 4 {
+		s += "." + parts[3]
+	}
+	return s
+}
+
+var _ Project = &DotNetProject{}
@@ -0,0 +1,75 @@
+package projectid
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+ElixirProject represents an Elixir project, the version is defined in mix.exs:
+  - by the `@version "x.y.z"` module attribute, used as `version: @version`
+  - or inline as `version: "x.y.z"` in `project/0`
+*/
+type ElixirProject struct {
+	workdir string
+}
+
+func (p *ElixirProject) versionFile() string {
+	return path.Join(p.workdir, "mix.exs")
+}
+
+func isElixir(workdir string) bool {
+	return utils.FileExists(path.Join(workdir, "mix.exs"))
+}
+
+func (p *ElixirProject) IsMe(workdir string) bool {
+	return isElixir(workdir)
+}
+
+func (p *ElixirProject) ID() ProjectID {
+	return Elixir
+}
+
+func (p *ElixirProject) WorkDir() string {
+	return p.workdir
+}
+
+var elixirAttributeVersionRE = regexp.MustCompile(`(?m)^\s*@version\s+"([^"]+)"`)
+var elixirKeywordVersionRE = regexp.MustCompile(`\bversion:\s*"([^"]+)"`)
+
+// versionRE returns the expression matching the version literal of mix.exs
+func (p *ElixirProject) versionRE() (*regexp.Regexp, error) {
+	for _, re := range []*regexp.Regexp{elixirAttributeVersionRE, elixirKeywordVersionRE} {
+		if _, err := utils.Grep(p.versionFile(), re); err == nil {
+			return re, nil
+		}
+	}
+	return nil, fmt.Errorf("version not found")
+}
+
+func (p *ElixirProject) GetVersion() (*version.Version, error) {
+	re, err := p.versionRE()
+	if err != nil {
+		return nil, err
+	}
+	v, err := utils.Grep(p.versionFile(), re)
+	if err != nil {
+		return nil, fmt.Errorf("version not found")
+	}
+
+	return version.Parse(v)
+}
+
+func (p *ElixirProject) SetVersion(v *version.Version) error {
+	re, err := p.versionRE()
+	if err != nil {
+		return err
+	}
+	return utils.Sed(p.versionFile(), re, v.String())
+}
+
+var _ Project = &ElixirProject{}
@@ -4,13 +4,23 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"strconv"
 
 	"github.com/elsejj/verit/internal/utils"
 	"github.com/elsejj/verit/pkg/version"
 )
 
+/*
+FlutterProject represents a Flutter or Dart project, the version is the `version` of pubspec.yaml,
+like `1.2.3+42`, where the build number `42` is required by app stores to increase on every release.
+
+on every version change, an existing build number follows the Options.VersionCode policy: increased
+by 1, the git commit count, a timestamp or a formula of the version, and it is never decreased.
+a numeric build in the version sets it explicitly.
+*/
 type FlutterProject struct {
 	workdir string
+	opts    *Options
 }
 
 func (p *FlutterProject) versionFile() string {
@@ -45,7 +55,29 @@ func (p *FlutterProject) GetVersion() (*version.Version, error) {
 }
 
 func (p *FlutterProject) SetVersion(v *version.Version) error {
-	return utils.Sed(p.versionFile(), flutterVersionRE, v.String())
+	current := -1
+	if s, err := utils.Grep(p.versionFile(), flutterVersionRE); err == nil {
+		if old, err := version.Parse(s); err == nil && old.Build != "" {
+			if current, err = strconv.Atoi(old.Build); err != nil && v.Build == "" {
+				return fmt.Errorf("build number %s is not an integer, set the build explicitly", old.Build)
+			}
+		}
+	}
+
+	next := *v
+	next.Build = ""
+	setBuild := v.Build != "" || (p.opts != nil && p.opts.VersionCode != "" && p.opts.VersionCode != "increment")
+	if current >= 0 || setBuild {
+		build, err := nextBuildNumber(p.opts, p.workdir, v, current)
+		if err != nil {
+			return err
+		}
+		if build < current {
+			return fmt.Errorf("build number can not decrease from %d to %d", current, build)
+		}
+		next.Build = strconv.Itoa(build)
+	}
+	return utils.Sed(p.versionFile(), flutterVersionRE, next.String())
 }
 
 var _ Project = &FlutterProject{}
@@ -16,9 +16,15 @@ Because Go projects do not have a standard version file, we flow the rules below
   - lookup the project by checking the existence of "version.txt"
   - this file can be embedded to a go variable use `go:embed` directive
   - the file content should be like `x.y.z`
+  - without version.txt, a top-level string constant or variable like `const Version = "x.y.z"`
+    is used, its name is set by Options.GoVersionName
+
+with Options.GoMajorPath, a new major version from v2 also moves the module to its `/vN` path:
+the module line of go.mod and the imports of the module are rewritten.
 */
 type GoProject struct {
 	workdir           string
+	opts              *Options
 	_versionFile      string
 	_versionFileFound bool
 }
@@ -50,8 +56,30 @@ func (p *GoProject) WorkDir() string {
 	return p.workdir
 }
 
+// versionName returns the name of the Go constant or variable holding the version
+func (p *GoProject) versionName() string {
+	if p.opts != nil && p.opts.GoVersionName != "" {
+		return p.opts.GoVersionName
+	}
+	return "Version"
+}
+
 func (p *GoProject) GetVersion() (*version.Version, error) {
 	versionFile := p.versionFile()
+	if versionFile == "" {
+		lit, err := findGoVersionLiteral(p.workdir, p.versionName())
+		if err != nil {
+			return nil, err
+		}
+		if lit == nil {
+			return nil, fmt.Errorf("version.txt or %s constant not found", p.versionName())
+		}
+		v, err := version.Parse(lit.value)
+		if err != nil {
+			return nil, fmt.Errorf("parse version from %s failed: %w", lit.file, err)
+		}
+		return v, nil
+	}
 	data, err := os.ReadFile(versionFile)
 	if err != nil {
 		return nil, fmt.Errorf("version.txt not found")
@@ -65,8 +93,35 @@ func (p *GoProject) GetVersion() (*version.Version, error) {
 
 func (p *GoProject) SetVersion(v *version.Version) error {
 	versionFile := p.versionFile()
+	var lit *goVersionLiteral
 	if versionFile == "" {
-		return fmt.Errorf("version.txt not found, please create one")
+		var err error
+		if lit, err = findGoVersionLiteral(p.workdir, p.versionName()); err != nil {
+			return err
+		}
+		if lit == nil {
+			return fmt.Errorf("version.txt or %s constant not found, please create one", p.versionName())
+		}
+	}
+	if p.opts != nil && p.opts.GoMajorPath {
+		refs, err := migrateGoMajor(p.workdir, v.Major)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			p.opts.warnf("%s still refers to the previous major version of the module", ref)
+		}
+	}
+	if lit != nil {
+		// the literal moves when the migration rewrites the imports of its file
+		lit, err := findGoVersionLiteral(p.workdir, p.versionName())
+		if err != nil {
+			return err
+		}
+		if lit == nil {
+			return fmt.Errorf("%s constant not found after moving the module", p.versionName())
+		}
+		return lit.set(v.String())
 	}
 	fp, err := os.Create(versionFile)
 	if err != nil {
@@ -0,0 +1,106 @@
+package projectid
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+GradleProject represents a Gradle project, the version is looked up in order:
+  - `version=x.y.z` in gradle.properties
+  - `version = "x.y.z"` in build.gradle.kts or build.gradle (groovy `version 'x.y.z'` works too)
+
+a short version like `1.0-SNAPSHOT` reads as `1.0.0-SNAPSHOT`.
+a version computed from an expression (e.g. `version = property("appVersion")`) is not supported.
+*/
+type GradleProject struct {
+	workdir string
+}
+
+type gradleVersionFile struct {
+	name string
+	re   *regexp.Regexp
+}
+
+var gradlePropertiesVersionRE = regexp.MustCompile(`(?m)^[ \t]*version[ \t]*[=:][ \t]*([^\s#!]+)`)
+
+var gradleBuildVersionRE = regexp.MustCompile(`(?m)^[ \t]*version[ \t]*=?[ \t]*["']([^"'$]+)["']`)
+
+var gradleVersionFiles = []gradleVersionFile{
+	{"gradle.properties", gradlePropertiesVersionRE},
+	{"build.gradle.kts", gradleBuildVersionRE},
+	{"build.gradle", gradleBuildVersionRE},
+}
+
+var gradleBuildFiles = []string{
+	"settings.gradle.kts",
+	"settings.gradle",
+	"build.gradle.kts",
+	"build.gradle",
+}
+
+// isGradle reports a plain Gradle project, Android apps define their version in their own way
+func isGradle(workdir string) bool {
+	if isAndroid(workdir) {
+		return false
+	}
+	for _, name := range gradleBuildFiles {
+		if utils.FileExists(path.Join(workdir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// versionFile returns the first file defining the version, with the expression to find it
+func (p *GradleProject) versionFile() (string, *regexp.Regexp, error) {
+	for _, f := range gradleVersionFiles {
+		fileName := path.Join(p.workdir, f.name)
+		if !utils.FileExists(fileName) {
+			continue
+		}
+		if _, err := utils.Grep(fileName, f.re); err == nil {
+			return fileName, f.re, nil
+		}
+	}
+	return "", nil, fmt.Errorf("version not found in gradle.properties, build.gradle.kts or build.gradle")
+}
+
+func (p *GradleProject) IsMe(workdir string) bool {
+	return isGradle(workdir)
+}
+
+func (p *GradleProject) ID() ProjectID {
+	return Gradle
+}
+
+func (p *GradleProject) WorkDir() string {
+	return p.workdir
+}
+
+func (p *GradleProject) GetVersion() (*version.Version, error) {
+	fileName, re, err := p.versionFile()
+	if err != nil {
+		return nil, err
+	}
+	v, err := utils.Grep(fileName, re)
+	if err != nil {
+		return nil, fmt.Errorf("version not found")
+	}
+
+	return parseShortVersion(v)
+}
+
+func (p *GradleProject) SetVersion(v *version.Version) error {
+	fileName, re, err := p.versionFile()
+	if err != nil {
+		return err
+	}
+	return utils.Sed(fileName, re, v.String())
+}
+
+var _ Project = &GradleProject{}
@@ -0,0 +1,228 @@
+package projectid
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+HelmProject represents a Helm chart, the version is the chart `version` of Chart.yaml.
+
+`appVersion` is left untouched unless Options.ChartAppVersion names the directory of the
+project it should follow, relative to the chart (`.` for the chart version itself).
+
+when the chart is a subchart of an umbrella chart, in its `charts/` directory or referenced by a
+`file://` repository, its entry in the `dependencies` of the umbrella Chart.yaml is updated too.
+*/
+type HelmProject struct {
+	workdir string
+	opts    *Options
+}
+
+func (p *HelmProject) versionFile() string {
+	return path.Join(p.workdir, "Chart.yaml")
+}
+
+func isHelm(workdir string) bool {
+	return utils.FileExists(path.Join(workdir, "Chart.yaml"))
+}
+
+func (p *HelmProject) IsMe(workdir string) bool {
+	return isHelm(workdir)
+}
+
+func (p *HelmProject) ID() ProjectID {
+	return Helm
+}
+
+func (p *HelmProject) WorkDir() string {
+	return p.workdir
+}
+
+var helmVersionRE = regexp.MustCompile(`(?m)^version:[ \t]*["']?([^\s"'#]+)["']?`)
+var helmAppVersionRE = regexp.MustCompile(`(?m)^appVersion:[ \t]*["']?([^\s"'#]+)["']?`)
+var helmNameRE = regexp.MustCompile(`(?m)^name:[ \t]*["']?([^\s"'#]+)["']?`)
+
+func (p *HelmProject) GetVersion() (*version.Version, error) {
+	v, err := utils.Grep(p.versionFile(), helmVersionRE)
+	if err != nil {
+		return nil, fmt.Errorf("version not found")
+	}
+
+	return version.Parse(v)
+}
+
+func (p *HelmProject) SetVersion(v *version.Version) error {
+	appVersion, err := p.appVersion(v)
+	if err != nil {
+		return err
+	}
+	fileName := p.versionFile()
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	m := helmVersionRE.FindSubmatchIndex(data)
+	if m == nil {
+		return fmt.Errorf("version not found in %s", fileName)
+	}
+	edits := []fileEdit{{m[2], m[3], v.String()}}
+	if appVersion != "" {
+		if a := helmAppVersionRE.FindSubmatchIndex(data); a != nil {
+			edits = append(edits, fileEdit{a[2], a[3], appVersion})
+		} else {
+			// add it on the line after the chart version
+			end := len(data)
+			if i := bytes.IndexByte(data[m[1]:], '\n'); i >= 0 {
+				end = m[1] + i + 1
+			}
+			raw := "appVersion: " + strconv.Quote(appVersion) + "\n"
+			if end == len(data) && !bytes.HasSuffix(data, []byte("\n")) {
+				raw = "\n" + raw
+			}
+			edits = append(edits, fileEdit{end, end, raw})
+		}
+	}
+	if err := applyFileEdits(fileName, data, edits); err != nil {
+		return err
+	}
+	return p.updateUmbrella(v.String())
+}
+
+// appVersion returns the appVersion to write along the chart version v, or "" to keep it
+func (p *HelmProject) appVersion(v *version.Version) (string, error) {
+	if p.opts == nil || p.opts.ChartAppVersion == "" {
+		return "", nil
+	}
+	dir := p.opts.ChartAppVersion
+	if !path.IsAbs(dir) {
+		dir = path.Join(p.workdir, dir)
+	}
+	if path.Clean(dir) == path.Clean(p.workdir) {
+		return v.String(), nil
+	}
+	id := Which(dir)
+	if id == 0 || id == Helm {
+		return "", fmt.Errorf("no application project in %s to follow for appVersion", dir)
+	}
+	app, err := id.Project(dir).GetVersion()
+	if err != nil {
+		return "", fmt.Errorf("%s project in %s: %w", id, dir, err)
+	}
+	return app.String(), nil
+}
+
+// updateUmbrella updates the entry of the chart in the dependencies of its umbrella chart
+func (p *HelmProject) updateUmbrella(to string) error {
+	name, err := utils.Grep(p.versionFile(), helmNameRE)
+	if err != nil {
+		return nil
+	}
+	dir := path.Clean(p.workdir)
+	for _, parent := range []string{path.Dir(dir), path.Dir(path.Dir(dir))} {
+		fileName := path.Join(parent, "Chart.yaml")
+		if parent == dir || !utils.FileExists(fileName) {
+			continue
+		}
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return err
+		}
+		var edits []fileEdit
+		for _, dep := range helmDependencies(data) {
+			if dep.name != name || dep.version == "" {
+				continue
+			}
+			// a subchart is vendored in charts/ or referenced by a local repository
+			local := path.Join(parent, "charts", name) == dir
+			if repo, ok := strings.CutPrefix(dep.repository, "file://"); ok {
+				local = local || path.Join(parent, repo) == dir
+			}
+			if !local {
+				continue
+			}
+			edits = append(edits, fileEdit{dep.start, dep.end, bumpRequirement(dep.version, to)})
+		}
+		if len(edits) == 0 {
+			continue
+		}
+		return applyFileEdits(fileName, data, edits)
+	}
+	return nil
+}
+
+// helmDependency is an entry of the `dependencies` list of Chart.yaml
+type helmDependency struct {
+	name       string
+	repository string
+	version    string
+	// start and end are the byte offsets of the unquoted version
+	start int
+	end   int
+}
+
+var helmDependencyKeyRE = regexp.MustCompile(`^(\s*)(-\s+)?(\w+):[ \t]*(.*?)[ \t]*(#.*)?$`)
+
+// helmDependencies scans the block style `dependencies` list of Chart.yaml
+func helmDependencies(data []byte) []*helmDependency {
+	var deps []*helmDependency
+	var current *helmDependency
+	inList := false
+	offset := 0
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		start := offset
+		offset += len(line)
+		text := strings.TrimRight(string(line), "\r\n")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(strings.TrimSpace(text), "#") {
+			continue
+		}
+		if !strings.HasPrefix(text, " ") && !strings.HasPrefix(text, "-") {
+			inList = strings.HasPrefix(text, "dependencies:")
+			current = nil
+			continue
+		}
+		if !inList {
+			continue
+		}
+		m := helmDependencyKeyRE.FindStringSubmatchIndex(text)
+		if m == nil {
+			continue
+		}
+		if m[4] >= 0 {
+			current = &helmDependency{}
+			deps = append(deps, current)
+		}
+		if current == nil {
+			continue
+		}
+		key := text[m[6]:m[7]]
+		valueStart, valueEnd := m[8], m[9]
+		value := text[valueStart:valueEnd]
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			valueStart++
+			valueEnd--
+			value = value[1 : len(value)-1]
+		}
+		switch key {
+		case "name":
+			current.name = value
+		case "repository":
+			current.repository = value
+		case "version":
+			current.version = value
+			current.start = start + valueStart
+			current.end = start + valueEnd
+		}
+	}
+	return deps
+}
+
+var _ Project = &HelmProject{}
@@ -0,0 +1,205 @@
+package projectid
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/internal/xml"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+MavenProject represents a Maven project, the version is the `<version>` of the project itself in pom.xml:
+  - the `<parent>` and dependency versions are never used
+  - a `-SNAPSHOT` suffix is the prerelease of the version, `1.0-SNAPSHOT` is `1.0.0-SNAPSHOT`
+  - a module without its own `<version>` inherits it from the parent, bumping it bumps the parent pom
+  - bumping a reactor pom also updates the `<parent>` version of its modules
+  - `${property}` references to `<properties>` (e.g. CI friendly `${revision}`) are resolved
+*/
+type MavenProject struct {
+	workdir string
+}
+
+func (p *MavenProject) versionFile() string {
+	return path.Join(p.workdir, "pom.xml")
+}
+
+func isMaven(workdir string) bool {
+	return utils.FileExists(path.Join(workdir, "pom.xml"))
+}
+
+func (p *MavenProject) IsMe(workdir string) bool {
+	return isMaven(workdir)
+}
+
+func (p *MavenProject) ID() ProjectID {
+	return Maven
+}
+
+func (p *MavenProject) WorkDir() string {
+	return p.workdir
+}
+
+// pom is a parsed pom.xml
+type pom struct {
+	file string
+	doc  *xml.Document
+}
+
+func loadPom(fileName string) (*pom, error) {
+	doc, err := xml.Load(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if root := doc.First("project"); root == nil {
+		return nil, fmt.Errorf("%s has no <project> element", fileName)
+	}
+	return &pom{file: fileName, doc: doc}, nil
+}
+
+func (m *pom) text(path ...string) string {
+	if e := m.doc.First(append([]string{"project"}, path...)...); e != nil {
+		return e.Text
+	}
+	return ""
+}
+
+// parent returns the pom of the `<parent>`, found by its relativePath, or nil
+// if it is not part of this source tree.
+func (m *pom) parent() (*pom, error) {
+	if m.doc.First("project", "parent") == nil {
+		return nil, nil
+	}
+	rel := m.text("parent", "relativePath")
+	if rel == "" {
+		rel = "../pom.xml"
+	}
+	fileName := path.Join(path.Dir(m.file), rel)
+	if !strings.HasSuffix(fileName, ".xml") {
+		fileName = path.Join(fileName, "pom.xml")
+	}
+	if !utils.FileExists(fileName) {
+		return nil, nil
+	}
+	parent, err := loadPom(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if parent.text("artifactId") != m.text("parent", "artifactId") {
+		return nil, nil
+	}
+	return parent, nil
+}
+
+var mavenPropertyRE = regexp.MustCompile(`^\$\{([^}]+)\}$`)
+
+// versionElement returns the element holding the version of the project: its
+// own `<version>`, or the property it references.
+func (m *pom) versionElement() *xml.Element {
+	e := m.doc.First("project", "version")
+	if e == nil {
+		return nil
+	}
+	if match := mavenPropertyRE.FindStringSubmatch(e.Text); match != nil {
+		if prop := m.doc.First("project", "properties", match[1]); prop != nil {
+			return prop
+		}
+	}
+	return e
+}
+
+// owner returns the pom defining the version of the project, walking up the
+// parents when the version is inherited.
+func (p *MavenProject) owner() (*pom, error) {
+	m, err := loadPom(p.versionFile())
+	if err != nil {
+		return nil, err
+	}
+	for m.versionElement() == nil {
+		parent, err := m.parent()
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			if v := m.text("parent", "version"); v != "" {
+				return nil, fmt.Errorf("version %s is inherited from parent %s which is not in this source tree", v, m.text("parent", "artifactId"))
+			}
+			return nil, fmt.Errorf("version not found")
+		}
+		m = parent
+	}
+	return m, nil
+}
+
+func (p *MavenProject) GetVersion() (*version.Version, error) {
+	m, err := p.owner()
+	if err != nil {
+		return nil, err
+	}
+	return parseShortVersion(m.versionElement().Text)
+}
+
+func (p *MavenProject) SetVersion(v *version.Version) error {
+	m, err := p.owner()
+	if err != nil {
+		return err
+	}
+	old := m.versionElement().Text
+	if err := m.doc.Apply(xml.SetText(m.versionElement(), v.String())); err != nil {
+		return err
+	}
+	if err := m.doc.Save(m.file); err != nil {
+		return err
+	}
+	return updateMavenModules(m, old, v.String())
+}
+
+// updateMavenModules updates the `<parent>` version of the reactor modules of m,
+// and their own `<version>` when it is kept in lockstep with the parent.
+func updateMavenModules(m *pom, from, to string) error {
+	for _, module := range m.doc.Find("project", "modules", "module") {
+		fileName := path.Join(path.Dir(m.file), module.Text)
+		if !strings.HasSuffix(fileName, ".xml") {
+			fileName = path.Join(fileName, "pom.xml")
+		}
+		if !utils.FileExists(fileName) {
+			continue
+		}
+		child, err := loadPom(fileName)
+		if err != nil {
+			return err
+		}
+		if child.text("parent", "artifactId") != m.text("artifactId") {
+			continue
+		}
+
+		var edits []xml.Edit
+		if e := child.doc.First("project", "parent", "version"); e != nil && e.Text == from {
+			edits = append(edits, xml.SetText(e, to))
+		}
+		own := child.versionElement()
+		if own != nil && own.Text == from {
+			edits = append(edits, xml.SetText(own, to))
+		}
+		if len(edits) > 0 {
+			if err := child.doc.Apply(edits...); err != nil {
+				return fmt.Errorf("update %s failed: %w", fileName, err)
+			}
+			if err := child.doc.Save(fileName); err != nil {
+				return err
+			}
+		}
+		// modules of a module inheriting the version follow it too
+		if own == nil || own.Text == from {
+			if err := updateMavenModules(child, from, to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var _ Project = &MavenProject{}
@@ -0,0 +1,68 @@
+package projectid
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+MesonProject represents a project built by Meson, the version is the `version:` keyword
+argument of the `project()` call in meson.build, like `project('x', 'c', version: '1.2.3')`.
+*/
+type MesonProject struct {
+	workdir string
+}
+
+func (p *MesonProject) versionFile() string {
+	return path.Join(p.workdir, "meson.build")
+}
+
+func isMeson(workdir string) bool {
+	return utils.FileExists(path.Join(workdir, "meson.build"))
+}
+
+func (p *MesonProject) IsMe(workdir string) bool {
+	return isMeson(workdir)
+}
+
+func (p *MesonProject) ID() ProjectID {
+	return Meson
+}
+
+func (p *MesonProject) WorkDir() string {
+	return p.workdir
+}
+
+var mesonProjectRE = regexp.MustCompile(`(?m)^[ \t]*project[ \t]*\(`)
+var mesonVersionRE = regexp.MustCompile(`\bversion\s*:\s*'([^']*)'`)
+
+func (p *MesonProject) GetVersion() (*version.Version, error) {
+	data, err := os.ReadFile(p.versionFile())
+	if err != nil {
+		return nil, err
+	}
+	start, end, ok := findCallArgument(data, mesonProjectRE, mesonVersionRE)
+	if !ok {
+		return nil, fmt.Errorf("version not found in project()")
+	}
+	return parseShortVersion(string(data[start:end]))
+}
+
+func (p *MesonProject) SetVersion(v *version.Version) error {
+	data, err := os.ReadFile(p.versionFile())
+	if err != nil {
+		return err
+	}
+	start, end, ok := findCallArgument(data, mesonProjectRE, mesonVersionRE)
+	if !ok {
+		return fmt.Errorf("version not found in project()")
+	}
+	return spliceFile(p.versionFile(), data, start, end, v.String())
+}
+
+var _ Project = &MesonProject{}
@@ -8,6 +8,7 @@ import (
 
 type MixProject struct {
 	workdir  string
+	opts     *Options
 	projects []Project
 }
 
@@ -24,7 +25,7 @@ func (p *MixProject) scanProjects() []Project {
 		if !ok || !checker(p.workdir) {
 			continue
 		}
-		sub := id.Project(p.workdir)
+		sub := id.ProjectWith(p.workdir, p.opts)
 		if sub == nil {
 			continue
 		}
@@ -0,0 +1,74 @@
+package projectid
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+NimProject represents a Nim package, the version is the `version = "x.y.z"` field of its `*.nimble` file.
+*/
+type NimProject struct {
+	workdir string
+}
+
+func (p *NimProject) versionFile() (string, error) {
+	entries, err := os.ReadDir(p.workdir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".nimble") {
+			return path.Join(p.workdir, entry.Name()), nil
+		}
+	}
+	return "", fmt.Errorf("*.nimble not found in %s", p.workdir)
+}
+
+func isNim(workdir string) bool {
+	_, err := (&NimProject{workdir: workdir}).versionFile()
+	return err == nil
+}
+
+func (p *NimProject) IsMe(workdir string) bool {
+	return isNim(workdir)
+}
+
+func (p *NimProject) ID() ProjectID {
+	return Nim
+}
+
+func (p *NimProject) WorkDir() string {
+	return p.workdir
+}
+
+var nimbleVersionRE = regexp.MustCompile(`(?m)^version\s*=\s*"([^"]+)"`)
+
+func (p *NimProject) GetVersion() (*version.Version, error) {
+	fileName, err := p.versionFile()
+	if err != nil {
+		return nil, err
+	}
+	v, err := utils.Grep(fileName, nimbleVersionRE)
+	if err != nil {
+		return nil, fmt.Errorf("version not found")
+	}
+
+	return version.Parse(v)
+}
+
+func (p *NimProject) SetVersion(v *version.Version) error {
+	fileName, err := p.versionFile()
+	if err != nil {
+		return err
+	}
+	return utils.Sed(fileName, nimbleVersionRE, v.String())
+}
+
+var _ Project = &NimProject{}
@@ -3,8 +3,8 @@ package projectid
 import (
 	"fmt"
 	"path"
-	"regexp"
 
+	"github.com/elsejj/verit/internal/json"
 	"github.com/elsejj/verit/internal/utils"
 	"github.com/elsejj/verit/pkg/version"
 )
@@ -33,20 +33,68 @@ func (p *NodeProject) WorkDir() string {
 	return p.workdir
 }
 
-var nodeVersionRE = regexp.MustCompile(`"version"\s*:\s*"(.+)"`)
-
 func (p *NodeProject) GetVersion() (*version.Version, error) {
-	v, err := utils.Grep(p.versionFile(), nodeVersionRE)
+	doc, err := json.Load(p.versionFile())
 	if err != nil {
+		return nil, err
+	}
+	v, ok := doc.GetString("version")
+	if !ok {
 		return nil, fmt.Errorf("version not found")
 	}
 
 	return version.Parse(v)
+}
+
+// nodeLockFiles are the lockfiles that embed the root package version.
+// pnpm-lock.yaml and yarn.lock do not record it, so they never need an update.
+var nodeLockFiles = []string{
+	"package-lock.json",
+	"npm-shrinkwrap.json",
+}
 
+// nodeLockVersionKeys are the places a lockfile records the root package version
+var nodeLockVersionKeys = [][]string{
+	{"version"},
+	{"packages", "", "version"},
 }
 
 func (p *NodeProject) SetVersion(v *version.Version) error {
-	return utils.Sed(p.versionFile(), nodeVersionRE, v.String())
+	// parse the lockfiles first, so a broken one leaves every file untouched
+	var lockFiles []string
+	var locks []*json.Document
+	for _, name := range nodeLockFiles {
+		fileName := path.Join(p.workdir, name)
+		if !utils.FileExists(fileName) {
+			continue
+		}
+		doc, err := json.Load(fileName)
+		if err != nil {
+			return err
+		}
+		lockFiles = append(lockFiles, fileName)
+		locks = append(locks, doc)
+	}
+
+	if err := setJSONString(p.versionFile(), v.String(), "version"); err != nil {
+		return err
+	}
+
+	for i, doc := range locks {
+		fileName := lockFiles[i]
+		for _, key := range nodeLockVersionKeys {
+			if _, ok := doc.GetString(key...); !ok {
+				continue
+			}
+			if err := doc.SetString(v.String(), key...); err != nil {
+				return fmt.Errorf("update %s failed: %w", fileName, err)
+			}
+		}
+		if err := doc.Save(fileName); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 var _ Project = &NodeProject{}
@@ -4,11 +4,26 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"strings"
 
+	"github.com/elsejj/verit/internal/toml"
 	"github.com/elsejj/verit/internal/utils"
 	"github.com/elsejj/verit/pkg/version"
 )
 
+/*
+PythonProject represents a Python project, the version is found in order:
+  - `[project] version` or `[tool.poetry] version` of pyproject.toml
+  - for a dynamic version, the file of `[tool.hatch.version] path`, or the `attr` or `file`
+    of `[tool.setuptools.dynamic] version`
+  - `version` of the `[metadata]` section of setup.cfg, a literal or an `attr:`/`file:` reference
+  - the `version="..."` literal of setup.py
+
+a module attribute like `pkg.__version__` is looked up in `src/pkg/__init__.py` or `pkg/__init__.py`,
+and in its `_version.py` sibling when `__init__.py` only imports it.
+
+versions are PEP 440, translated from and to semver by version.ParsePEP440 and Version.PEP440.
+*/
 type PythonProject struct {
 	workdir string
 }
@@ -18,7 +33,9 @@ func (p *PythonProject) versionFile() string {
 }
 
 func isPython(workdir string) bool {
-	return utils.FileExists(path.Join(workdir, "pyproject.toml"))
+	return utils.FileExists(path.Join(workdir, "pyproject.toml")) ||
+		utils.FileExists(path.Join(workdir, "setup.cfg")) ||
+		utils.FileExists(path.Join(workdir, "setup.py"))
 }
 
 func (p *PythonProject) IsMe(workdir string) bool {
@@ -33,19 +50,135 @@ func (p *PythonProject) WorkDir() string {
 	return p.workdir
 }
 
-var pythonVersionRE = regexp.MustCompile(`\s*version\s*=\s*"(.+)"`)
+// pythonVersionKeys lists where pyproject.toml may define the version, in order of precedence
+var pythonVersionKeys = [][]string{
+	{"project", "version"},
+	{"tool", "poetry", "version"},
+}
+
+// pythonVersionSource is where the version of a Python project is defined: a string of
+// pyproject.toml, or the single capture group of re in file.
+type pythonVersionSource struct {
+	file string
+	re   *regexp.Regexp
+}
+
+var pythonSetupCfgVersionRE = regexp.MustCompile(`(?m)^\[metadata\][^\[]*?^version[ \t]*[=:][ \t]*([^\r\n]*?)[ \t]*$`)
+var pythonSetupPyVersionRE = regexp.MustCompile(`\bversion\s*=\s*["']([^"']+)["']`)
+var pythonVersionFileRE = regexp.MustCompile(`\A\s*(\S+)`)
+
+// hatch's default pattern for the file of `[tool.hatch.version] path`
+var pythonHatchVersionRE = regexp.MustCompile(`(?m)^(?:__version__|VERSION)\s*(?::[^=\n]*)?=\s*["']([^"']+)["']`)
+
+// versionSource returns where the version is defined, nil for pyproject.toml
+func (p *PythonProject) versionSource() (*pythonVersionSource, error) {
+	if utils.FileExists(p.versionFile()) {
+		doc, err := toml.Load(p.versionFile())
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := findTOMLString(doc, pythonVersionKeys); ok {
+			return nil, nil
+		}
+		if source, ok := doc.GetString("tool", "hatch", "version", "source"); ok && source != "regex" {
+			return nil, fmt.Errorf("version is provided by the hatch %s source, it is not managed in a file", source)
+		}
+		if file, ok := doc.GetString("tool", "hatch", "version", "path"); ok {
+			return &pythonVersionSource{file: path.Join(p.workdir, file), re: pythonHatchVersionRE}, nil
+		}
+		if attr, ok := doc.GetString("tool", "setuptools", "dynamic", "version", "attr"); ok {
+			return p.attrSource(attr)
+		}
+		if file, ok := doc.GetString("tool", "setuptools", "dynamic", "version", "file"); ok {
+			return &pythonVersionSource{file: path.Join(p.workdir, file), re: pythonVersionFileRE}, nil
+		}
+	}
+
+	setupCfg := path.Join(p.workdir, "setup.cfg")
+	if value, err := utils.Grep(setupCfg, pythonSetupCfgVersionRE); err == nil {
+		if attr, ok := strings.CutPrefix(value, "attr:"); ok {
+			return p.attrSource(strings.TrimSpace(attr))
+		}
+		if file, ok := strings.CutPrefix(value, "file:"); ok {
+			return &pythonVersionSource{file: path.Join(p.workdir, strings.TrimSpace(file)), re: pythonVersionFileRE}, nil
+		}
+		return &pythonVersionSource{file: setupCfg, re: pythonSetupCfgVersionRE}, nil
+	}
+
+	setupPy := path.Join(p.workdir, "setup.py")
+	if _, err := utils.Grep(setupPy, pythonSetupPyVersionRE); err == nil {
+		return &pythonVersionSource{file: setupPy, re: pythonSetupPyVersionRE}, nil
+	}
+	return nil, fmt.Errorf("version not found")
+}
+
+// attrSource returns the module file assigning the attribute attr, like `pkg.__version__`
+func (p *PythonProject) attrSource(attr string) (*pythonVersionSource, error) {
+	i := strings.LastIndex(attr, ".")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid version attribute %s", attr)
+	}
+	module := strings.ReplaceAll(attr[:i], ".", "/")
+	re := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(attr[i+1:]) + `\s*(?::[^=\n]*)?=\s*["']([^"']+)["']`)
+
+	for _, root := range []string{"src", ""} {
+		for _, file := range []string{module + ".py", path.Join(module, "__init__.py")} {
+			file = path.Join(p.workdir, root, file)
+			if !utils.FileExists(file) {
+				continue
+			}
+			if _, err := utils.Grep(file, re); err == nil {
+				return &pythonVersionSource{file: file, re: re}, nil
+			}
+			// `from ._version import __version__`
+			sibling := path.Join(path.Dir(file), "_version.py")
+			if _, err := utils.Grep(sibling, re); err == nil {
+				return &pythonVersionSource{file: sibling, re: re}, nil
+			}
+			return nil, fmt.Errorf("%s is not assigned a literal in %s", attr, file)
+		}
+	}
+	return nil, fmt.Errorf("module of version attribute %s not found", attr)
+}
 
 func (p *PythonProject) GetVersion() (*version.Version, error) {
-	v, err := utils.Grep(p.versionFile(), pythonVersionRE)
+	source, err := p.versionSource()
+	if err != nil {
+		return nil, err
+	}
+	if source != nil {
+		v, err := utils.Grep(source.file, source.re)
+		if err != nil {
+			return nil, fmt.Errorf("version not found in %s", source.file)
+		}
+		return version.ParsePEP440(v)
+	}
+
+	doc, err := toml.Load(p.versionFile())
 	if err != nil {
+		return nil, err
+	}
+	v, ok := findTOMLString(doc, pythonVersionKeys)
+	if !ok {
 		return nil, fmt.Errorf("version not found")
 	}
 
-	return version.Parse(v)
+	return version.ParsePEP440(v)
 }
 
 func (p *PythonProject) SetVersion(v *version.Version) error {
-	return utils.Sed(p.versionFile(), pythonVersionRE, v.String())
+	s, err := v.PEP440()
+	if err != nil {
+		return err
+	}
+	source, err := p.versionSource()
+	if err != nil {
+		return err
+	}
+	if source != nil {
+		return utils.Sed(source.file, source.re, s)
+	}
+	return setTOMLString(p.versionFile(), pythonVersionKeys, s)
 }
 
 var _ Project = &PythonProject{}
@@ -0,0 +1,150 @@
+package projectid
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+RubyProject represents a Ruby gem, the version is found through the `*.gemspec`:
+  - a literal `spec.version = "x.y.z"` in the gemspec
+  - or the `VERSION = "x.y.z"` constant it references, in the file it requires,
+    by convention `lib/<name>/version.rb`
+
+the `PATH` entry of the gem itself in Gemfile.lock is kept in sync.
+*/
+type RubyProject struct {
+	workdir string
+}
+
+func (p *RubyProject) gemspec() (string, error) {
+	entries, err := os.ReadDir(p.workdir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".gemspec") {
+			return path.Join(p.workdir, entry.Name()), nil
+		}
+	}
+	return "", fmt.Errorf("*.gemspec not found in %s", p.workdir)
+}
+
+func isRuby(workdir string) bool {
+	_, err := (&RubyProject{workdir: workdir}).gemspec()
+	return err == nil
+}
+
+func (p *RubyProject) IsMe(workdir string) bool {
+	return isRuby(workdir)
+}
+
+func (p *RubyProject) ID() ProjectID {
+	return Ruby
+}
+
+func (p *RubyProject) WorkDir() string {
+	return p.workdir
+}
+
+var gemspecVersionRE = regexp.MustCompile(`(?m)^\s*\w+\.version\s*=\s*["']([^"']+)["']`)
+var gemspecVersionConstRE = regexp.MustCompile(`(?m)^\s*\w+\.version\s*=\s*[A-Z][\w:]*VERSION\b`)
+var gemspecNameRE = regexp.MustCompile(`(?m)^\s*\w+\.name\s*=\s*["']([^"']+)["']`)
+var gemspecRequireRE = regexp.MustCompile(`(?m)^\s*(require|require_relative)\s*\(?\s*["']([^"']*version)["']`)
+var rubyVersionRE = regexp.MustCompile(`(?m)^\s*VERSION\s*=\s*["']([^"']+)["']`)
+
+// gemName returns the name of the gem, as declared in the gemspec or from its file name
+func gemName(gemspec string) string {
+	if name, err := utils.Grep(gemspec, gemspecNameRE); err == nil {
+		return name
+	}
+	return strings.TrimSuffix(path.Base(gemspec), ".gemspec")
+}
+
+// versionFile returns the file holding the version literal, with the expression to find it
+func (p *RubyProject) versionFile() (string, *regexp.Regexp, error) {
+	gemspec, err := p.gemspec()
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := utils.Grep(gemspec, gemspecVersionRE); err == nil {
+		return gemspec, gemspecVersionRE, nil
+	}
+	if _, err := utils.Grep(gemspec, gemspecVersionConstRE); err != nil {
+		return "", nil, fmt.Errorf("version not found in %s", gemspec)
+	}
+
+	var candidates []string
+	data, err := os.ReadFile(gemspec)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, m := range gemspecRequireRE.FindAllStringSubmatch(string(data), -1) {
+		if m[1] == "require_relative" {
+			candidates = append(candidates, path.Join(p.workdir, m[2]+".rb"))
+		} else {
+			candidates = append(candidates, path.Join(p.workdir, "lib", m[2]+".rb"))
+		}
+	}
+	// gem `foo-bar` conventionally keeps its version in lib/foo/bar/version.rb
+	name := gemName(gemspec)
+	candidates = append(candidates,
+		path.Join(p.workdir, "lib", strings.ReplaceAll(name, "-", "/"), "version.rb"),
+		path.Join(p.workdir, "lib", name, "version.rb"),
+	)
+	for _, fileName := range candidates {
+		if !utils.FileExists(fileName) {
+			continue
+		}
+		if _, err := utils.Grep(fileName, rubyVersionRE); err == nil {
+			return fileName, rubyVersionRE, nil
+		}
+	}
+	return "", nil, fmt.Errorf("VERSION constant of %s not found", gemspec)
+}
+
+func (p *RubyProject) GetVersion() (*version.Version, error) {
+	fileName, re, err := p.versionFile()
+	if err != nil {
+		return nil, err
+	}
+	v, err := utils.Grep(fileName, re)
+	if err != nil {
+		return nil, fmt.Errorf("version not found")
+	}
+
+	return version.Parse(v)
+}
+
+func (p *RubyProject) SetVersion(v *version.Version) error {
+	fileName, re, err := p.versionFile()
+	if err != nil {
+		return err
+	}
+	if err := utils.Sed(fileName, re, v.String()); err != nil {
+		return err
+	}
+
+	lockFile := path.Join(p.workdir, "Gemfile.lock")
+	if !utils.FileExists(lockFile) {
+		return nil
+	}
+	gemspec, err := p.gemspec()
+	if err != nil {
+		return err
+	}
+	// the gem itself is listed under the specs of the `PATH` section, like `    foo (1.2.3)`
+	lockRE := regexp.MustCompile(`(?m)^PATH\r?\n(?:  .*\n)*?    ` + regexp.QuoteMeta(gemName(gemspec)) + ` \(([^)]+)\)`)
+	if _, err := utils.Grep(lockFile, lockRE); err != nil {
+		return nil
+	}
+	return utils.Sed(lockFile, lockRE, v.String())
+}
+
+var _ Project = &RubyProject{}
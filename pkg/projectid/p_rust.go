@@ -3,7 +3,7 @@ package projectid
 import (
 	"fmt"
 	"path"
-	"regexp"
+	"slices"
 
 	"github.com/elsejj/verit/internal/utils"
 	"github.com/elsejj/verit/pkg/version"
@@ -11,6 +11,7 @@ import (
 
 type RustProject struct {
 	workdir string
+	opts    *Options
 }
 
 func (p *RustProject) versionFile() string {
@@ -33,19 +34,142 @@ func (p *RustProject) WorkDir() string {
 	return p.workdir
 }
 
-var RustVersionRE = regexp.MustCompile(`\s*version\s*=\s*"(.+)"`)
+// rustPackageVersionKey and rustWorkspaceVersionKey are where Cargo.toml defines the version
+var (
+	rustPackageVersionKey   = []string{"package", "version"}
+	rustWorkspaceVersionKey = []string{"workspace", "package", "version"}
+)
 
-func (p *RustProject) GetVersion() (*version.Version, error) {
-	v, err := utils.Grep(p.versionFile(), RustVersionRE)
+// manifest returns the Cargo.toml to manage: the one in workdir, or the one of
+// the workspace member selected by Options.Crate.
+func (p *RustProject) manifest() (*cargoManifest, error) {
+	if p.opts == nil || p.opts.Crate == "" {
+		return loadCargoManifest(p.workdir)
+	}
+	ws, err := findCargoWorkspace(p.workdir)
+	if err != nil {
+		return nil, fmt.Errorf("crate %s: %w", p.opts.Crate, err)
+	}
+	members, err := ws.workspaceMembers()
 	if err != nil {
-		return nil, fmt.Errorf("version not found")
+		return nil, err
 	}
+	for _, m := range members {
+		if m.packageName() == p.opts.Crate {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("crate %s not found in workspace %s", p.opts.Crate, ws.dir())
+}
 
+// versionSource returns the manifest and key that define the version of the crate in workdir:
+// its own [package] version, or the [workspace.package] version of the workspace root when
+// it inherits it with `version.workspace = true` or is a virtual workspace.
+func (p *RustProject) versionSource() (*cargoManifest, []string, error) {
+	m, err := p.manifest()
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := m.doc.GetString(rustPackageVersionKey...); ok {
+		return m, rustPackageVersionKey, nil
+	}
+
+	ws := m
+	if m.inheritsVersion() {
+		if ws, err = findCargoWorkspace(m.dir()); err != nil {
+			return nil, nil, err
+		}
+	}
+	if _, ok := ws.doc.GetString(rustWorkspaceVersionKey...); ok {
+		return ws, rustWorkspaceVersionKey, nil
+	}
+	if ws != m {
+		return nil, nil, fmt.Errorf("version.workspace is set but %s has no [workspace.package] version", ws.file)
+	}
+	return nil, nil, fmt.Errorf("version not found")
+}
+
+func (p *RustProject) GetVersion() (*version.Version, error) {
+	m, key, err := p.versionSource()
+	if err != nil {
+		return nil, err
+	}
+	v, _ := m.doc.GetString(key...)
 	return version.Parse(v)
 }
 
 func (p *RustProject) SetVersion(v *version.Version) error {
-	return utils.Sed(p.versionFile(), RustVersionRE, v.String())
+	m, key, err := p.versionSource()
+	if err != nil {
+		return err
+	}
+	old, _ := m.doc.GetString(key...)
+	if err := m.doc.SetString(v.String(), key...); err != nil {
+		return err
+	}
+	if err := m.doc.Save(m.file); err != nil {
+		return err
+	}
+
+	// the crates whose version changed, to keep Cargo.lock in sync
+	var names []string
+	if slices.Equal(key, rustPackageVersionKey) {
+		names = append(names, m.packageName())
+	} else {
+		crates, err := m.workspaceMembers()
+		if err != nil {
+			return err
+		}
+		for _, c := range crates {
+			if c.inheritsVersion() {
+				names = append(names, c.packageName())
+			}
+		}
+	}
+
+	var updates []cargoUpdate
+	for _, name := range names {
+		if name != "" {
+			updates = append(updates, cargoUpdate{name: name, from: old, to: v.String()})
+		}
+	}
+
+	// keep the requirements on the bumped crates satisfiable in the other workspace members
+	if ws, err := findCargoWorkspace(m.dir()); err == nil {
+		if err := updateCargoDependents(ws, updates); err != nil {
+			return err
+		}
+	}
+	return updateCargoLock(m.dir(), updates)
+}
+
+// Crate is a member of a Cargo workspace
+type Crate struct {
+	Name    string
+	Dir     string
+	Version *version.Version
+}
+
+// Crates returns the members of the Cargo workspace containing workdir, with their versions.
+func (p *RustProject) Crates() ([]Crate, error) {
+	ws, err := findCargoWorkspace(p.workdir)
+	if err != nil {
+		return nil, err
+	}
+	members, err := ws.workspaceMembers()
+	if err != nil {
+		return nil, err
+	}
+	var crates []Crate
+	for _, m := range members {
+		member := &RustProject{workdir: m.dir()}
+		v, err := member.GetVersion()
+		if err != nil {
+			return nil, fmt.Errorf("crate %s: %w", m.packageName(), err)
+		}
+		crates = append(crates, Crate{Name: m.packageName(), Dir: m.dir(), Version: v})
+	}
+	return crates, nil
 }
 
 var _ Project = &RustProject{}
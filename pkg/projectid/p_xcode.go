@@ -0,0 +1,184 @@
+package projectid
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+XcodeProject represents an iOS/macOS app, the version is kept in two settings:
+  - `MARKETING_VERSION` is the version, numeric only, like `1.2.3` (a short `1.2` reads as `1.2.0`)
+  - `CURRENT_PROJECT_VERSION` is the build number, it is the build of the version, like `1.2.3+42`
+
+they are managed in every build configuration of `*.xcodeproj/project.pbxproj`, and in
+`CFBundleShortVersionString`/`CFBundleVersion` of the Info.plist files when those are literals.
+the build number follows Options.VersionCode on every version change, like FlutterProject does, and is never decreased.
+
+Swift packages have no version in their manifest, they are versioned by git tags only.
+*/
+type XcodeProject struct {
+	workdir string
+	opts    *Options
+}
+
+func xcodeProjectFiles(workdir string) []string {
+	files, _ := filepath.Glob(filepath.Join(workdir, "*.xcodeproj", "project.pbxproj"))
+	return files
+}
+
+func isXcode(workdir string) bool {
+	return len(xcodeProjectFiles(workdir)) > 0
+}
+
+func (p *XcodeProject) IsMe(workdir string) bool {
+	return isXcode(workdir)
+}
+
+func (p *XcodeProject) ID() ProjectID {
+	return Xcode
+}
+
+func (p *XcodeProject) WorkDir() string {
+	return p.workdir
+}
+
+var xcodeMarketingVersionRE = regexp.MustCompile(`(?m)^\s*MARKETING_VERSION = "?([^";\s]+)"?;`)
+var xcodeBuildVersionRE = regexp.MustCompile(`(?m)^\s*CURRENT_PROJECT_VERSION = "?([^";\s]+)"?;`)
+var plistShortVersionRE = regexp.MustCompile(`<key>CFBundleShortVersionString</key>\s*<string>([^$<][^<]*)</string>`)
+var plistBuildVersionRE = regexp.MustCompile(`<key>CFBundleVersion</key>\s*<string>([^$<][^<]*)</string>`)
+
+// xcodeSetting is a setting holding the version, or the build number, in a file
+type xcodeSetting struct {
+	file  string
+	re    *regexp.Regexp
+	build bool
+}
+
+func (p *XcodeProject) settings() []xcodeSetting {
+	var settings []xcodeSetting
+	add := func(file string, re *regexp.Regexp, build bool) {
+		if values, err := utils.GrepAll(file, re); err == nil && len(values) > 0 {
+			settings = append(settings, xcodeSetting{file: file, re: re, build: build})
+		}
+	}
+	for _, file := range xcodeProjectFiles(p.workdir) {
+		add(file, xcodeMarketingVersionRE, false)
+		add(file, xcodeBuildVersionRE, true)
+	}
+	plists, _ := filepath.Glob(filepath.Join(p.workdir, "Info.plist"))
+	nested, _ := filepath.Glob(filepath.Join(p.workdir, "*", "Info.plist"))
+	for _, file := range append(plists, nested...) {
+		add(file, plistShortVersionRE, false)
+		add(file, plistBuildVersionRE, true)
+	}
+	return settings
+}
+
+// xcodeValue returns the single value shared by the settings of a kind, or "" if there are none
+func xcodeValue(settings []xcodeSetting, build bool) (string, error) {
+	var values []string
+	for _, s := range settings {
+		if s.build != build {
+			continue
+		}
+		found, err := utils.GrepAll(s.file, s.re)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, found...)
+	}
+	slices.Sort(values)
+	values = slices.Compact(values)
+	if len(values) > 1 {
+		name := "MARKETING_VERSION"
+		if build {
+			name = "CURRENT_PROJECT_VERSION"
+		}
+		return "", fmt.Errorf("inconsistent %s across build configurations: %v", name, values)
+	}
+	if len(values) == 0 {
+		return "", nil
+	}
+	return values[0], nil
+}
+
+var xcodeShortVersionRE = regexp.MustCompile(`^\d+\.\d+$`)
+
+func (p *XcodeProject) GetVersion() (*version.Version, error) {
+	settings := p.settings()
+	s, err := xcodeValue(settings, false)
+	if err != nil {
+		return nil, err
+	}
+	if s == "" {
+		return nil, fmt.Errorf("MARKETING_VERSION not found")
+	}
+	if xcodeShortVersionRE.MatchString(s) {
+		s += ".0"
+	}
+	v, err := version.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if v.Build, err = xcodeValue(settings, true); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (p *XcodeProject) SetVersion(v *version.Version) error {
+	if v.Prerelease != "" {
+		return fmt.Errorf("xcode versions are numeric only, prerelease %s is not supported", v.Prerelease)
+	}
+	settings := p.settings()
+	if len(settings) == 0 {
+		return fmt.Errorf("MARKETING_VERSION not found")
+	}
+
+	current := -1
+	old, err := xcodeValue(settings, true)
+	if err != nil {
+		return err
+	}
+	if old != "" {
+		if current, err = strconv.Atoi(old); err != nil && v.Build == "" {
+			return fmt.Errorf("CURRENT_PROJECT_VERSION %s is not an integer, set the build explicitly", old)
+		}
+	}
+
+	build := ""
+	setBuild := v.Build != "" || (p.opts != nil && p.opts.VersionCode != "" && p.opts.VersionCode != "increment")
+	if setBuild && old == "" {
+		return fmt.Errorf("CURRENT_PROJECT_VERSION not found, the build number can not be set")
+	}
+	if old != "" {
+		n, err := nextBuildNumber(p.opts, p.workdir, v, current)
+		if err != nil {
+			return err
+		}
+		if n < current {
+			return fmt.Errorf("CURRENT_PROJECT_VERSION can not decrease from %d to %d", current, n)
+		}
+		build = strconv.Itoa(n)
+	}
+
+	marketing := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	for _, s := range settings {
+		value := marketing
+		if s.build {
+			value = build
+		}
+		if err := utils.SedAll(s.file, s.re, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ Project = &XcodeProject{}
@@ -0,0 +1,55 @@
+package projectid
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/elsejj/verit/internal/utils"
+	"github.com/elsejj/verit/pkg/version"
+)
+
+/*
+ZigProject represents a Zig package, the version is the `.version = "x.y.z"` field of build.zig.zon.
+*/
+type ZigProject struct {
+	workdir string
+}
+
+func (p *ZigProject) versionFile() string {
+	return path.Join(p.workdir, "build.zig.zon")
+}
+
+func isZig(workdir string) bool {
+	return utils.FileExists(path.Join(workdir, "build.zig.zon"))
+}
+
+func (p *ZigProject) IsMe(workdir string) bool {
+	return isZig(workdir)
+}
+
+func (p *ZigProject) ID() ProjectID {
+	return Zig
+}
+
+func (p *ZigProject) WorkDir() string {
+	return p.workdir
+}
+
+// `.minimum_zig_version` is not matched
+var zigVersionRE = regexp.MustCompile(`(?m)^\s*\.version\s*=\s*"([^"]+)"`)
+
+func (p *ZigProject) GetVersion() (*version.Version, error) {
+	v, err := utils.Grep(p.versionFile(), zigVersionRE)
+	if err != nil {
+		return nil, fmt.Errorf("version not found")
+	}
+
+	return version.Parse(v)
+}
+
+func (p *ZigProject) SetVersion(v *version.Version) error {
+	return utils.Sed(p.versionFile(), zigVersionRE, v.String())
+}
+
+var _ Project = &ZigProject{}
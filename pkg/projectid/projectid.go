@@ -12,14 +12,46 @@ var projectDetectionOrder = []ProjectID{
 	Node,
 	Flutter,
 	Rust,
+	Maven,
+	Gradle,
+	Android,
+	DotNet,
+	Composer,
+	Ruby,
+	Elixir,
+	Xcode,
+	Helm,
+	CMake,
+	Meson,
+	Autotools,
+	Deno,
+	Zig,
+	Nim,
+	Crystal,
 }
 
 var projectCheckers = map[ProjectID]func(string) bool{
-	Node:    isNode,
-	Python:  isPython,
-	Go:      isGo,
-	Flutter: isFlutter,
-	Rust:    isRust,
+	Node:      isNode,
+	Python:    isPython,
+	Go:        isGo,
+	Flutter:   isFlutter,
+	Rust:      isRust,
+	Maven:     isMaven,
+	Gradle:    isGradle,
+	Android:   isAndroid,
+	DotNet:    isDotNet,
+	Composer:  isComposer,
+	Ruby:      isRuby,
+	Elixir:    isElixir,
+	Xcode:     isXcode,
+	Helm:      isHelm,
+	CMake:     isCMake,
+	Meson:     isMeson,
+	Autotools: isAutotools,
+	Deno:      isDeno,
+	Zig:       isZig,
+	Nim:       isNim,
+	Crystal:   isCrystal,
 }
 
 // Project represents a generic project with versioning capabilities
@@ -36,6 +68,34 @@ type Project interface {
 	SetVersion(v *version.Version) error
 }
 
+// Options tunes how projects locate and write their version, the zero value keeps the defaults
+type Options struct {
+	// Crate selects a crate by name inside a Cargo workspace, for crates versioned independently
+	Crate string
+	// VersionCode is how the build number, Android versionCode or Flutter `+N`, follows the
+	// version: "increment" (default), "git", "timestamp" or a formula like "major*10000+minor*100+patch"
+	VersionCode string
+	// CreateVersion allows adding the version field to manifests which have none,
+	// it is set when the version is set explicitly
+	CreateVersion bool
+	// ChartAppVersion is the directory, relative to a Helm chart, of the project whose
+	// version the chart appVersion follows, "." for the chart version itself
+	ChartAppVersion string
+	// GoMajorPath moves a Go module to the `/vN` path of a new major version
+	GoMajorPath bool
+	// GoVersionName is the name of the Go string constant or variable holding the version
+	// when there is no version.txt, "Version" by default
+	GoVersionName string
+	// Warnf reports a problem which does not stop the operation, it may be nil
+	Warnf func(format string, args ...any)
+}
+
+func (o *Options) warnf(format string, args ...any) {
+	if o != nil && o.Warnf != nil {
+		o.Warnf(format, args...)
+	}
+}
+
 // Pwd returns the current working directory
 func Pwd() string {
 	pwd, _ := os.Getwd()
@@ -1,10 +1,13 @@
 package projectid
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/elsejj/verit/pkg/version"
 )
@@ -158,6 +161,1559 @@ version: 1.2.3+4
 	assertFileContains(t, filepath.Join(dir, "pubspec.yaml"), `version: 1.2.4+5`)
 }
 
+func TestPythonProjectUsesProjectTable(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pyproject.toml", `[tool.something]
+version = "9.9.9"
+
+[project]
+name = "demo"
+# the package version
+version = "1.2.3"
+dependencies = ["foo"]
+`)
+
+	project := Python.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.3" {
+		t.Fatalf("expected version 1.2.3, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "pyproject.toml"), `[tool.something]
+version = "9.9.9"
+
+[project]
+name = "demo"
+# the package version
+version = "1.3.0"
+`)
+}
+
+func TestPythonProjectDynamicVersion(t *testing.T) {
+	dynamic := `[project]
+name = "demo"
+dynamic = ["version"]
+`
+	cases := []struct {
+		name    string
+		files   map[string]string
+		changed string
+		want    string
+	}{
+		{
+			name: "hatch path",
+			files: map[string]string{
+				"pyproject.toml":        dynamic + "\n[tool.hatch.version]\npath = \"src/demo/__about__.py\"\n",
+				"src/demo/__about__.py": "__version__ = \"1.2.3\"\n",
+				"src/demo/__init__.py":  "__version__ = \"0.0.0\"\n",
+			},
+			changed: "src/demo/__about__.py",
+			want:    `__version__ = "1.3.0"`,
+		},
+		{
+			name: "setuptools attr",
+			files: map[string]string{
+				"pyproject.toml":       dynamic + "\n[tool.setuptools.dynamic]\nversion = {attr = \"demo.__version__\"}\n",
+				"src/demo/__init__.py": "from ._version import __version__\n",
+				"src/demo/_version.py": "__version__: str = '1.2.3'\n",
+			},
+			changed: "src/demo/_version.py",
+			want:    `__version__: str = '1.3.0'`,
+		},
+		{
+			name: "setuptools file",
+			files: map[string]string{
+				"pyproject.toml": dynamic + "\n[tool.setuptools.dynamic]\nversion = {file = \"VERSION\"}\n",
+				"VERSION":        "1.2.3\n",
+			},
+			changed: "VERSION",
+			want:    "1.3.0\n",
+		},
+		{
+			name: "setup.cfg",
+			files: map[string]string{
+				"setup.cfg": "[metadata]\nname = demo\nversion = 1.2.3\n\n[options]\npackages = find:\n",
+				"setup.py":  "from setuptools import setup\nsetup()\n",
+			},
+			changed: "setup.cfg",
+			want:    "version = 1.3.0\n\n[options]",
+		},
+		{
+			name: "setup.cfg attr",
+			files: map[string]string{
+				"setup.cfg":        "[metadata]\nname = demo\nversion = attr: demo.__version__\n",
+				"demo/__init__.py": "__version__ = \"1.2.3\"\n",
+			},
+			changed: "demo/__init__.py",
+			want:    `__version__ = "1.3.0"`,
+		},
+		{
+			name: "setup.py",
+			files: map[string]string{
+				"setup.py": "from setuptools import setup\n\nsetup(\n    name=\"demo\",\n    version=\"1.2.3\",\n    python_requires=\">=3.8\",\n)\n",
+			},
+			changed: "setup.py",
+			want:    `version="1.3.0",`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range c.files {
+				writeFile(t, dir, name, content)
+			}
+
+			if got := Which(dir); got != Python {
+				t.Fatalf("expected Python, got %v", got)
+			}
+
+			project := Python.Project(dir)
+			v, err := project.GetVersion()
+			if err != nil {
+				t.Fatalf("get version: %v", err)
+			}
+			if v.String() != "1.2.3" {
+				t.Fatalf("expected version 1.2.3, got %s", v)
+			}
+
+			newVersion, _ := version.Parse("1.3.0")
+			if err := project.SetVersion(newVersion); err != nil {
+				t.Fatalf("set version: %v", err)
+			}
+			assertFileContains(t, filepath.Join(dir, c.changed), c.want)
+		})
+	}
+}
+
+func TestPythonProjectVCSVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pyproject.toml", `[project]
+name = "demo"
+dynamic = ["version"]
+
+[tool.hatch.version]
+source = "vcs"
+`)
+
+	if _, err := Python.Project(dir).GetVersion(); err == nil || !strings.Contains(err.Error(), "vcs") {
+		t.Fatalf("expected an error about the vcs source, got %v", err)
+	}
+}
+
+func TestPythonProjectPEP440Version(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pyproject.toml", `[project]
+name = "demo"
+version = "1.2.3.dev4"
+`)
+
+	project := Python.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.3-dev.4" {
+		t.Fatalf("expected version 1.2.3-dev.4, got %s", v)
+	}
+
+	v.Prerelease = "rc.1"
+	if err := project.SetVersion(v); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "pyproject.toml"), `version = "1.2.3rc1"`)
+
+	v.Prerelease = "snapshot"
+	if err := project.SetVersion(v); err == nil {
+		t.Fatalf("expected prerelease snapshot to be rejected")
+	}
+}
+
+func TestFlutterProjectBuildNumber(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pubspec.yaml", "name: demo\nversion: 1.2.3+41\n")
+	pubspec := filepath.Join(dir, "pubspec.yaml")
+
+	project := Flutter.Project(dir)
+	newVersion, _ := version.Parse("1.2.4")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, pubspec, "version: 1.2.4+42\n")
+
+	lower, _ := version.Parse("1.2.5+7")
+	if err := project.SetVersion(lower); err == nil {
+		t.Fatalf("expected the build number not to decrease")
+	}
+
+	now := nowFunc
+	nowFunc = func() time.Time { return time.Unix(1700000000, 0) }
+	defer func() { nowFunc = now }()
+	project = Flutter.ProjectWith(dir, &Options{VersionCode: "timestamp"})
+	newVersion, _ = version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, pubspec, "version: 1.3.0+1700000000\n")
+
+	writeFile(t, dir, "pubspec.yaml", "name: demo\nversion: 1.3.0\n")
+	project = Flutter.Project(dir)
+	newVersion, _ = version.Parse("1.3.1")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, pubspec, "version: 1.3.1\n")
+}
+
+func TestFlutterProjectGitBuildNumber(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	dir := t.TempDir()
+	writeFile(t, dir, "pubspec.yaml", "name: demo\nversion: 1.2.3+1\n")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=verit", "-c", "user.email=verit@example.com", "commit", "-q", "--allow-empty", "-m", "one"},
+		{"-c", "user.name=verit", "-c", "user.email=verit@example.com", "commit", "-q", "--allow-empty", "-m", "two"},
+		{"-c", "user.name=verit", "-c", "user.email=verit@example.com", "commit", "-q", "--allow-empty", "-m", "three"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+	}
+
+	project := Flutter.ProjectWith(dir, &Options{VersionCode: "git"})
+	newVersion, _ := version.Parse("1.2.4")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "pubspec.yaml"), "version: 1.2.4+3\n")
+}
+
+func TestRustProjectUsesPackageTable(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Cargo.toml", `[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+
+[package]
+name = "demo"
+version = "0.4.2" # bumped by verit
+`)
+
+	project := Rust.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "0.4.2" {
+		t.Fatalf("expected version 0.4.2, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("0.5.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "Cargo.toml"), `serde = { version = "1.0", features = ["derive"] }`)
+	assertFileContains(t, filepath.Join(dir, "Cargo.toml"), `version = "0.5.0" # bumped by verit`)
+}
+
+func TestNodeProjectUsesTopLevelVersion(t *testing.T) {
+	dir := t.TempDir()
+	content := `{
+  "name": "demo",
+  "engines": {
+    "version": "0.0.1"
+  },
+  "version": "1.2.3"
+}
+`
+	writeFile(t, dir, "package.json", content)
+
+	project := Node.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.3" {
+		t.Fatalf("expected version 1.2.3, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.2.4")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	if want := strings.Replace(content, `"1.2.3"`, `"1.2.4"`, 1); string(data) != want {
+		t.Fatalf("unexpected package.json:\n%s", data)
+	}
+}
+
+func TestNodeProjectUpdatesLockFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "package.json", `{"name":"demo","version":"1.2.3"}`)
+	writeFile(t, dir, "package-lock.json", `{
+  "name": "demo",
+  "version": "1.2.3",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {
+      "name": "demo",
+      "version": "1.2.3"
+    },
+    "node_modules/foo": {
+      "version": "1.2.3"
+    }
+  }
+}
+`)
+	writeFile(t, dir, "npm-shrinkwrap.json", `{"name":"demo","version":"1.2.3","lockfileVersion":1}`)
+
+	newVersion, _ := version.Parse("2.0.0")
+	if err := Node.Project(dir).SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "package-lock.json"), `  "version": "2.0.0",
+  "lockfileVersion": 3,`)
+	assertFileContains(t, filepath.Join(dir, "package-lock.json"), `      "name": "demo",
+      "version": "2.0.0"`)
+	assertFileContains(t, filepath.Join(dir, "package-lock.json"), `"node_modules/foo": {
+      "version": "1.2.3"`)
+	assertFileContains(t, filepath.Join(dir, "npm-shrinkwrap.json"), `"version":"2.0.0"`)
+}
+
+func TestRustProjectUpdatesCargoLock(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Cargo.toml", `[package]
+name = "demo"
+version = "0.1.0"
+`)
+	writeFile(t, dir, "Cargo.lock", `# This file is automatically @generated by Cargo.
+version = 4
+
+[[package]]
+name = "demo"
+version = "0.1.0"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "demo"
+version = "0.1.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "serde"
+version = "1.0.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`)
+
+	newVersion, _ := version.Parse("0.2.0")
+	if err := Rust.Project(dir).SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "Cargo.lock"), `name = "demo"
+version = "0.2.0"
+dependencies`)
+	assertFileContains(t, filepath.Join(dir, "Cargo.lock"), `name = "demo"
+version = "0.1.0"
+source`)
+}
+
+func TestRustWorkspaceInheritedVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Cargo.toml", `[workspace]
+members = ["crates/*"]
+exclude = ["crates/legacy"]
+
+[workspace.package]
+version = "1.2.3"
+edition = "2021"
+`)
+	writeFile(t, dir, "crates/a/Cargo.toml", `[package]
+name = "a"
+version.workspace = true
+`)
+	writeFile(t, dir, "crates/b/Cargo.toml", `[package]
+name = "b"
+version = { workspace = true }
+`)
+	writeFile(t, dir, "crates/legacy/Cargo.toml", `[package]
+name = "legacy"
+version.workspace = true
+`)
+	writeFile(t, dir, "Cargo.lock", `version = 4
+
+[[package]]
+name = "a"
+version = "1.2.3"
+
+[[package]]
+name = "b"
+version = "1.2.3"
+
+[[package]]
+name = "legacy"
+version = "1.2.3"
+`)
+
+	member := Rust.Project(filepath.Join(dir, "crates", "a"))
+	v, err := member.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.3" {
+		t.Fatalf("expected version 1.2.3, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := member.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "Cargo.toml"), `[workspace.package]
+version = "1.3.0"`)
+	assertFileContains(t, filepath.Join(dir, "crates", "a", "Cargo.toml"), `version.workspace = true`)
+	assertFileContains(t, filepath.Join(dir, "Cargo.lock"), `name = "a"
+version = "1.3.0"`)
+	assertFileContains(t, filepath.Join(dir, "Cargo.lock"), `name = "b"
+version = "1.3.0"`)
+	assertFileContains(t, filepath.Join(dir, "Cargo.lock"), `name = "legacy"
+version = "1.2.3"`)
+
+	v, err = Rust.Project(dir).GetVersion()
+	if err != nil {
+		t.Fatalf("get root version: %v", err)
+	}
+	if v.String() != "1.3.0" {
+		t.Fatalf("expected root version 1.3.0, got %s", v)
+	}
+}
+
+func TestRustWorkspaceIndependentCrates(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Cargo.toml", `[workspace]
+members = ["core", "cli"]
+
+[workspace.dependencies]
+demo-core = { path = "core", version = "1.0.0" }
+`)
+	writeFile(t, dir, "core/Cargo.toml", `[package]
+name = "demo-core"
+version = "1.0.0"
+`)
+	writeFile(t, dir, "cli/Cargo.toml", `[package]
+name = "demo-cli"
+version = "0.5.0"
+
+[dependencies]
+core = { package = "demo-core", path = "../core", version = "^1.0.0" }
+serde = { version = "1.0.0" }
+
+[dev-dependencies.demo-core]
+path = "../core"
+version = "=1.0.0"
+`)
+
+	crates, err := (&RustProject{workdir: dir}).Crates()
+	if err != nil {
+		t.Fatalf("list crates: %v", err)
+	}
+	if len(crates) != 2 || crates[0].Name != "demo-core" || crates[1].Version.String() != "0.5.0" {
+		t.Fatalf("unexpected crates: %+v", crates)
+	}
+
+	project := Rust.ProjectWith(dir, &Options{Crate: "demo-core"})
+	newVersion, _ := version.Parse("1.1.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "core", "Cargo.toml"), `version = "1.1.0"`)
+	assertFileContains(t, filepath.Join(dir, "cli", "Cargo.toml"), `name = "demo-cli"
+version = "0.5.0"`)
+	assertFileContains(t, filepath.Join(dir, "cli", "Cargo.toml"), `core = { package = "demo-core", path = "../core", version = "^1.1.0" }
+serde = { version = "1.0.0" }`)
+	assertFileContains(t, filepath.Join(dir, "cli", "Cargo.toml"), `version = "=1.1.0"`)
+	assertFileContains(t, filepath.Join(dir, "Cargo.toml"), `demo-core = { path = "core", version = "1.1.0" }`)
+
+	if _, err := Rust.ProjectWith(dir, &Options{Crate: "missing"}).GetVersion(); err == nil {
+		t.Fatalf("expected error for unknown crate")
+	}
+}
+
+func TestMavenReactorVersionOperations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pom.xml", `<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>3.2.0</version>
+  </parent>
+  <groupId>com.example</groupId>
+  <artifactId>demo</artifactId>
+  <version>1.2.3-SNAPSHOT</version>
+  <packaging>pom</packaging>
+  <modules>
+    <module>api</module>
+  </modules>
+  <dependencies>
+    <dependency>
+      <artifactId>lib</artifactId>
+      <version>1.2.3-SNAPSHOT</version>
+    </dependency>
+  </dependencies>
+</project>
+`)
+	writeFile(t, dir, "api/pom.xml", `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>demo</artifactId>
+    <version>1.2.3-SNAPSHOT</version>
+  </parent>
+  <artifactId>demo-api</artifactId>
+</project>
+`)
+
+	module := Maven.Project(filepath.Join(dir, "api"))
+	v, err := module.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.Prerelease != "SNAPSHOT" || v.String() != "1.2.3-SNAPSHOT" {
+		t.Fatalf("unexpected version: %+v", v)
+	}
+
+	newVersion, _ := version.Parse("1.2.3")
+	if err := module.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "pom.xml"), `<version>3.2.0</version>`)
+	assertFileContains(t, filepath.Join(dir, "pom.xml"), `<artifactId>demo</artifactId>
+  <version>1.2.3</version>`)
+	assertFileContains(t, filepath.Join(dir, "pom.xml"), `<artifactId>lib</artifactId>
+      <version>1.2.3-SNAPSHOT</version>`)
+	assertFileContains(t, filepath.Join(dir, "api", "pom.xml"), `<artifactId>demo</artifactId>
+    <version>1.2.3</version>`)
+}
+
+func TestMavenPropertyVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pom.xml", `<project>
+  <artifactId>demo</artifactId>
+  <version>${revision}</version>
+  <properties>
+    <revision>0.9.0</revision>
+  </properties>
+</project>
+`)
+
+	project := Maven.Project(dir)
+	newVersion, _ := version.Parse("1.0.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "pom.xml"), `<version>${revision}</version>`)
+	assertFileContains(t, filepath.Join(dir, "pom.xml"), `<revision>1.0.0</revision>`)
+}
+
+func TestMavenShortSnapshotVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pom.xml", `<project>
+  <artifactId>demo</artifactId>
+  <version>1.0-SNAPSHOT</version>
+  <modules>
+    <module>api</module>
+  </modules>
+</project>
+`)
+	writeFile(t, dir, "api/pom.xml", `<project>
+  <parent>
+    <artifactId>demo</artifactId>
+    <version>1.0-SNAPSHOT</version>
+  </parent>
+  <artifactId>demo-api</artifactId>
+</project>
+`)
+
+	project := Maven.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.0.0-SNAPSHOT" {
+		t.Fatalf("unexpected version: %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.1.0-SNAPSHOT")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "pom.xml"), `<version>1.1.0-SNAPSHOT</version>`)
+	assertFileContains(t, filepath.Join(dir, "api", "pom.xml"), `<version>1.1.0-SNAPSHOT</version>`)
+}
+
+func TestGradleProjectVersionOperations(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		file  string
+		want  string
+	}{
+		{
+			name: "gradle.properties",
+			files: map[string]string{
+				"settings.gradle.kts": `rootProject.name = "demo"`,
+				"gradle.properties":   "org.gradle.jvmargs=-Xmx2g\nversion=1.2.3\n",
+				"build.gradle.kts":    `version = "0.0.1"`,
+			},
+			file: "gradle.properties",
+			want: "version=2.0.0\n",
+		},
+		{
+			name: "build.gradle.kts",
+			files: map[string]string{
+				"build.gradle.kts": `plugins {
+    id("org.jetbrains.kotlin.jvm") version "1.9.0"
+}
+
+group = "com.example"
+version = "1.2.3"
+`,
+			},
+			file: "build.gradle.kts",
+			want: `id("org.jetbrains.kotlin.jvm") version "1.9.0"
+}
+
+group = "com.example"
+version = "2.0.0"`,
+		},
+		{
+			name: "groovy build.gradle",
+			files: map[string]string{
+				"build.gradle": "group 'com.example'\nversion '1.2.3'\n",
+			},
+			file: "build.gradle",
+			want: "version '2.0.0'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeFile(t, dir, name, content)
+			}
+			if got := Which(dir); got != Gradle {
+				t.Fatalf("expected Gradle, got %v", got)
+			}
+
+			project := Gradle.Project(dir)
+			v, err := project.GetVersion()
+			if err != nil {
+				t.Fatalf("get version: %v", err)
+			}
+			if v.String() != "1.2.3" {
+				t.Fatalf("expected version 1.2.3, got %s", v)
+			}
+
+			newVersion, _ := version.Parse("2.0.0")
+			if err := project.SetVersion(newVersion); err != nil {
+				t.Fatalf("set version: %v", err)
+			}
+			assertFileContains(t, filepath.Join(dir, tt.file), tt.want)
+		})
+	}
+}
+
+func TestGradleShortVersion(t *testing.T) {
+	dir := t.TempDir()
+	// the default of `gradle init`
+	writeFile(t, dir, "build.gradle.kts", `plugins {
+    application
+}
+
+group = "org.example"
+version = "1.0-SNAPSHOT"
+`)
+
+	project := Gradle.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.0.0-SNAPSHOT" {
+		t.Fatalf("expected version 1.0.0-SNAPSHOT, got %s", v)
+	}
+	v.Patch++
+	if err := project.SetVersion(v); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "build.gradle.kts"), `version = "1.0.1-SNAPSHOT"`)
+
+	writeFile(t, dir, "gradle.properties", "version=1.0\n")
+	if v, err := project.GetVersion(); err != nil || v.String() != "1.0.0" {
+		t.Fatalf("expected version 1.0.0 from gradle.properties, got %v, %v", v, err)
+	}
+}
+
+func TestAndroidProjectVersionOperations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "settings.gradle.kts", `include(":app")`)
+	writeFile(t, dir, "app/build.gradle.kts", `android {
+    defaultConfig {
+        applicationId = "com.example.demo"
+        versionCode = 41
+        versionName = "1.2.3"
+    }
+}
+`)
+
+	if got := Which(dir); got != Android {
+		t.Fatalf("expected Android, got %v", got)
+	}
+
+	project := Android.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.3+41" {
+		t.Fatalf("expected version 1.2.3+41, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "app", "build.gradle.kts"), `versionCode = 42
+        versionName = "1.3.0"`)
+
+	lower, _ := version.Parse("1.3.1+7")
+	if err := project.SetVersion(lower); err == nil {
+		t.Fatalf("expected error when decreasing versionCode")
+	}
+
+	formula := Android.ProjectWith(dir, &Options{VersionCode: "major*10000+minor*100+patch"})
+	newVersion, _ = version.Parse("2.1.5")
+	if err := formula.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "app", "build.gradle.kts"), `versionCode = 20105`)
+}
+
+func TestAndroidProjectShortVersionName(t *testing.T) {
+	dir := t.TempDir()
+	// the default of the Android Studio new project template
+	writeFile(t, dir, "app/build.gradle.kts", `android {
+    defaultConfig {
+        applicationId = "com.example.demo"
+        versionCode = 1
+        versionName = "1.0"
+    }
+}
+`)
+
+	project := Android.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.0.0+1" {
+		t.Fatalf("expected version 1.0.0+1, got %s", v)
+	}
+	v.Patch++
+	v.Build = ""
+	if err := project.SetVersion(v); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "app", "build.gradle.kts"), `versionName = "1.0.1"`)
+	assertFileContains(t, filepath.Join(dir, "app", "build.gradle.kts"), `versionCode = 2`)
+}
+
+func TestAndroidManifestVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "AndroidManifest.xml", `<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+    package="com.example.demo"
+    android:versionCode="7"
+    android:versionName="0.9.0">
+</manifest>
+`)
+
+	project := Android.Project(dir)
+	newVersion, _ := version.Parse("1.0.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "AndroidManifest.xml"), `android:versionCode="8"
+    android:versionName="1.0.0"`)
+}
+
+func TestDotNetProjectVersionOperations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Demo.csproj", `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageReference Include="Newtonsoft.Json" Version="13.0.3" />
+  </ItemGroup>
+</Project>
+`)
+	writeFile(t, dir, "Directory.Build.props", `<Project>
+  <PropertyGroup>
+    <VersionPrefix>1.2.3</VersionPrefix>
+    <VersionSuffix>beta.1</VersionSuffix>
+    <AssemblyVersion>1.0.0.0</AssemblyVersion>
+    <FileVersion>1.2.3.17</FileVersion>
+  </PropertyGroup>
+</Project>
+`)
+
+	if got := Which(dir); got != DotNet {
+		t.Fatalf("expected DotNet, got %v", got)
+	}
+
+	project := DotNet.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.3-beta.1" {
+		t.Fatalf("expected version 1.2.3-beta.1, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "Directory.Build.props"), `<VersionPrefix>1.3.0</VersionPrefix>
+    <VersionSuffix></VersionSuffix>
+    <AssemblyVersion>1.3.0.0</AssemblyVersion>
+    <FileVersion>1.3.0.17</FileVersion>`)
+
+	writeFile(t, dir, "Demo.csproj", `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <Version>2.0.0-rc.1</Version>
+  </PropertyGroup>
+</Project>
+`)
+	newVersion, _ = version.Parse("2.0.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "Demo.csproj"), `<Version>2.0.0</Version>`)
+	assertFileContains(t, filepath.Join(dir, "Directory.Build.props"), `<VersionPrefix>1.3.0</VersionPrefix>`)
+}
+
+func TestComposerProjectVersionOperations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "composer.json", `{
+    "name": "acme/demo",
+    "type": "project",
+    "require": {
+        "php": "^8.2"
+    }
+}
+`)
+	writeFile(t, dir, "package.json", `{"name":"demo","version":"1.2.3"}`)
+
+	if got := Which(dir); got != Mix {
+		t.Fatalf("expected Mix, got %v", got)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := Composer.Project(dir).SetVersion(newVersion); err == nil {
+		t.Fatalf("expected error when version is absent")
+	}
+
+	project := Mix.ProjectWith(dir, &Options{CreateVersion: true})
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "composer.json"), `{
+    "name": "acme/demo",
+    "version": "1.3.0",
+    "type": "project",`)
+
+	v, err := Mix.Project(dir).GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.3.0" {
+		t.Fatalf("expected version 1.3.0, got %s", v)
+	}
+}
+
+func TestRubyProjectVersionOperations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "acme-demo.gemspec", `# frozen_string_literal: true
+
+require_relative "lib/acme/demo/version"
+
+Gem::Specification.new do |spec|
+  spec.name = "acme-demo"
+  spec.version = Acme::Demo::VERSION
+  spec.required_ruby_version = ">= 3.0.0"
+end
+`)
+	writeFile(t, dir, "lib/acme/demo/version.rb", `module Acme
+  module Demo
+    VERSION = "0.3.1"
+  end
+end
+`)
+	writeFile(t, dir, "Gemfile.lock", `PATH
+  remote: .
+  specs:
+    acme-demo (0.3.1)
+      rack (~> 3.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    acme-demo (0.3.1)
+    rack (3.0.8)
+`)
+
+	if got := Which(dir); got != Ruby {
+		t.Fatalf("expected Ruby, got %v", got)
+	}
+
+	project := Ruby.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "0.3.1" {
+		t.Fatalf("expected version 0.3.1, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("0.4.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "lib", "acme", "demo", "version.rb"), `VERSION = "0.4.0"`)
+	assertFileContains(t, filepath.Join(dir, "acme-demo.gemspec"), `spec.version = Acme::Demo::VERSION`)
+	assertFileContains(t, filepath.Join(dir, "Gemfile.lock"), `  specs:
+    acme-demo (0.4.0)
+      rack (~> 3.0)`)
+	assertFileContains(t, filepath.Join(dir, "Gemfile.lock"), `  specs:
+    acme-demo (0.3.1)
+    rack (3.0.8)`)
+}
+
+func TestRubyGemspecLiteralVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "demo.gemspec", `Gem::Specification.new do |s|
+  s.name    = 'demo'
+  s.version = '1.0.0'
+end
+`)
+
+	newVersion, _ := version.Parse("1.0.1")
+	if err := Ruby.Project(dir).SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "demo.gemspec"), `s.version = '1.0.1'`)
+}
+
+func TestElixirProjectVersionOperations(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "module attribute",
+			content: `defmodule Demo.MixProject do
+  use Mix.Project
+
+  @version "1.2.3"
+
+  def project do
+    [app: :demo, version: @version, elixir: "~> 1.15"]
+  end
+end
+`,
+			want: `@version "1.3.0"`,
+		},
+		{
+			name: "inline keyword",
+			content: `defmodule Demo.MixProject do
+  use Mix.Project
+
+  def project do
+    [
+      app: :demo,
+      version: "1.2.3",
+      deps: [{:phoenix, "~> 1.7"}]
+    ]
+  end
+end
+`,
+			want: `version: "1.3.0",`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, dir, "mix.exs", tt.content)
+			if got := Which(dir); got != Elixir {
+				t.Fatalf("expected Elixir, got %v", got)
+			}
+
+			project := Elixir.Project(dir)
+			v, err := project.GetVersion()
+			if err != nil {
+				t.Fatalf("get version: %v", err)
+			}
+			if v.String() != "1.2.3" {
+				t.Fatalf("expected version 1.2.3, got %s", v)
+			}
+
+			newVersion, _ := version.Parse("1.3.0")
+			if err := project.SetVersion(newVersion); err != nil {
+				t.Fatalf("set version: %v", err)
+			}
+			assertFileContains(t, filepath.Join(dir, "mix.exs"), tt.want)
+		})
+	}
+}
+
+func TestXcodeProjectVersionOperations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Demo.xcodeproj/project.pbxproj", `		A1 /* Debug */ = {
+			buildSettings = {
+				CURRENT_PROJECT_VERSION = 41;
+				MARKETING_VERSION = 1.2;
+			};
+		};
+		A2 /* Release */ = {
+			buildSettings = {
+				CURRENT_PROJECT_VERSION = 41;
+				MARKETING_VERSION = 1.2;
+			};
+		};
+`)
+	writeFile(t, dir, "Demo/Info.plist", `<dict>
+	<key>CFBundleShortVersionString</key>
+	<string>$(MARKETING_VERSION)</string>
+	<key>CFBundleVersion</key>
+	<string>41</string>
+</dict>
+`)
+
+	if got := Which(dir); got != Xcode {
+		t.Fatalf("expected Xcode, got %v", got)
+	}
+
+	project := Xcode.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.0+41" {
+		t.Fatalf("expected version 1.2.0+41, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	pbxproj := filepath.Join(dir, "Demo.xcodeproj", "project.pbxproj")
+	data, _ := os.ReadFile(pbxproj)
+	if strings.Count(string(data), "MARKETING_VERSION = 1.3.0;") != 2 || strings.Count(string(data), "CURRENT_PROJECT_VERSION = 42;") != 2 {
+		t.Fatalf("unexpected project.pbxproj:\n%s", data)
+	}
+	assertFileContains(t, filepath.Join(dir, "Demo", "Info.plist"), `<string>$(MARKETING_VERSION)</string>`)
+	assertFileContains(t, filepath.Join(dir, "Demo", "Info.plist"), `<string>42</string>`)
+
+	lower, _ := version.Parse("1.3.1+5")
+	if err := project.SetVersion(lower); err == nil {
+		t.Fatalf("expected error when decreasing build number")
+	}
+	pre, _ := version.Parse("1.4.0-beta.1")
+	if err := project.SetVersion(pre); err == nil {
+		t.Fatalf("expected error for prerelease")
+	}
+}
+
+func TestXcodeProjectBuildNumberPolicy(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Demo.xcodeproj/project.pbxproj", "\t\t\t\tCURRENT_PROJECT_VERSION = 41;\n\t\t\t\tMARKETING_VERSION = 1.2.3;\n")
+
+	project := Xcode.ProjectWith(dir, &Options{VersionCode: "major*10000+minor*100+patch"})
+	newVersion, _ := version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "Demo.xcodeproj", "project.pbxproj"), "CURRENT_PROJECT_VERSION = 10300;")
+
+	// without CURRENT_PROJECT_VERSION, there is nowhere to write the build number
+	writeFile(t, dir, "Demo.xcodeproj/project.pbxproj", "\t\t\t\tMARKETING_VERSION = 1.2.3;\n")
+	explicit, _ := version.Parse("1.3.0+7")
+	if err := Xcode.Project(dir).SetVersion(explicit); err == nil {
+		t.Fatalf("expected error when setting a build without CURRENT_PROJECT_VERSION")
+	}
+	assertFileContains(t, filepath.Join(dir, "Demo.xcodeproj", "project.pbxproj"), "MARKETING_VERSION = 1.2.3;")
+	if err := Xcode.Project(dir).SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "Demo.xcodeproj", "project.pbxproj"), "MARKETING_VERSION = 1.3.0;")
+}
+
+func TestHelmProjectVersionOperations(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "Chart.yaml", `apiVersion: v2
+name: platform
+version: 0.4.0
+dependencies:
+  - name: api
+    version: "~1.2.0"
+    repository: "file://charts/api"
+  - name: redis
+    version: 1.2.0
+    repository: https://charts.bitnami.com/bitnami
+`)
+	dir := filepath.Join(root, "charts", "api")
+	writeFile(t, dir, "Chart.yaml", `apiVersion: v2
+name: api
+version: "1.2.0" # chart version
+appVersion: "2.0.0"
+`)
+	writeFile(t, root, "app/package.json", `{"name": "api", "version": "2.1.0"}`)
+
+	if got := Which(dir); got != Helm {
+		t.Fatalf("expected Helm, got %v", got)
+	}
+
+	project := Helm.ProjectWith(dir, &Options{ChartAppVersion: "../../app"})
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.0" {
+		t.Fatalf("expected version 1.2.0, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	chart := filepath.Join(dir, "Chart.yaml")
+	assertFileContains(t, chart, `version: "1.3.0" # chart version`)
+	assertFileContains(t, chart, `appVersion: "2.1.0"`)
+
+	umbrella := filepath.Join(root, "Chart.yaml")
+	assertFileContains(t, umbrella, `version: "~1.3.0"`)
+	assertFileContains(t, umbrella, "version: 0.4.0\n")
+	assertFileContains(t, umbrella, "    version: 1.2.0\n")
+
+	project = Helm.ProjectWith(dir, &Options{ChartAppVersion: "."})
+	newVersion, _ = version.Parse("1.4.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, chart, `appVersion: "1.4.0"`)
+}
+
+func TestHelmProjectAddsAppVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Chart.yaml", "apiVersion: v2\nname: api\nversion: 0.1.0\ntype: application\n")
+	chart := filepath.Join(dir, "Chart.yaml")
+
+	project := Helm.ProjectWith(dir, &Options{ChartAppVersion: "missing"})
+	newVersion, _ := version.Parse("0.2.0")
+	if err := project.SetVersion(newVersion); err == nil {
+		t.Fatalf("expected an error for a missing application project")
+	}
+	assertFileContains(t, chart, "version: 0.1.0\n")
+
+	project = Helm.ProjectWith(dir, &Options{ChartAppVersion: "."})
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, chart, "version: 0.2.0\nappVersion: \"0.2.0\"\ntype: application\n")
+}
+
+func TestCMakeProjectVersionOperations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "CMakeLists.txt", `cmake_minimum_required(VERSION 3.16)
+project(
+  demo
+  VERSION 1.2
+  LANGUAGES CXX
+)
+add_subdirectory(vendor)
+project(vendored VERSION 9.9.9)
+`)
+
+	if got := Which(dir); got != CMake {
+		t.Fatalf("expected CMake, got %v", got)
+	}
+
+	project := CMake.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.0" {
+		t.Fatalf("expected version 1.2.0, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	cmake := filepath.Join(dir, "CMakeLists.txt")
+	assertFileContains(t, cmake, "  VERSION 1.3.0\n  LANGUAGES CXX")
+	assertFileContains(t, cmake, "cmake_minimum_required(VERSION 3.16)")
+	assertFileContains(t, cmake, "project(vendored VERSION 9.9.9)")
+
+	for _, s := range []string{"1.4.0-rc.1", "1.4.0+4", "1.4.0+abc"} {
+		invalid, _ := version.Parse(s)
+		if err := project.SetVersion(invalid); err == nil {
+			t.Fatalf("expected %s to be rejected", s)
+		}
+	}
+	assertFileContains(t, cmake, "  VERSION 1.3.0\n")
+
+	writeFile(t, dir, "CMakeLists.txt", "project(demo VERSION 1.2.3.4)\n")
+	if _, err := project.GetVersion(); err == nil {
+		t.Fatalf("expected the tweak of 1.2.3.4 to be rejected")
+	}
+}
+
+func TestMesonProjectVersionOperations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "meson.build", `project(
+  'demo',
+  'c',
+  version: '1.2',
+  meson_version: '>= 0.60.0',
+  default_options: ['warning_level=3'],
+)
+dep = dependency('zlib', version: '>=1.2.8')
+`)
+
+	if got := Which(dir); got != Meson {
+		t.Fatalf("expected Meson, got %v", got)
+	}
+
+	project := Meson.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.0" {
+		t.Fatalf("expected version 1.2.0, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0-rc.1")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	meson := filepath.Join(dir, "meson.build")
+	assertFileContains(t, meson, "  version: '1.3.0-rc.1',\n  meson_version: '>= 0.60.0',")
+	assertFileContains(t, meson, "version: '>=1.2.8'")
+}
+
+func TestAutotoolsProjectVersionOperations(t *testing.T) {
+	for _, init := range []string{
+		"AC_INIT([demo], [1.2.3], [bugs@example.com])",
+		"AC_INIT(demo, 1.2.3)",
+		"AC_INIT([demo],\n        [1.2.3],\n        [bugs@example.com])",
+	} {
+		dir := t.TempDir()
+		writeFile(t, dir, "configure.ac", "AC_PREREQ([2.69])\n"+init+"\nAM_INIT_AUTOMAKE([foreign])\n")
+
+		if got := Which(dir); got != Autotools {
+			t.Fatalf("expected Autotools, got %v", got)
+		}
+
+		project := Autotools.Project(dir)
+		v, err := project.GetVersion()
+		if err != nil {
+			t.Fatalf("get version of %s: %v", init, err)
+		}
+		if v.String() != "1.2.3" {
+			t.Fatalf("expected version 1.2.3, got %s", v)
+		}
+
+		newVersion, _ := version.Parse("1.3.0")
+		if err := project.SetVersion(newVersion); err != nil {
+			t.Fatalf("set version: %v", err)
+		}
+		configure := filepath.Join(dir, "configure.ac")
+		assertFileContains(t, configure, strings.Replace(init, "1.2.3", "1.3.0", 1))
+		assertFileContains(t, configure, "AC_PREREQ([2.69])")
+	}
+}
+
+func TestGoProjectMajorPathMigration(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "go.mod", "module example.com/demo\n\ngo 1.24\n")
+	writeFile(t, dir, "version.txt", "1.4.2")
+	writeFile(t, dir, "main.go", `package main
+
+import (
+	"fmt"
+
+	"example.com/demo/internal/greet"
+	"example.com/demolition"
+)
+
+// see https://pkg.go.dev/example.com/demo
+func main() {
+	fmt.Println(greet.Hello(), demolition.X)
+}
+`)
+	writeFile(t, dir, "internal/greet/greet.go", "package greet\n\nimport _ \"example.com/demo\"\n\nfunc Hello() string { return \"hello\" }\n")
+	writeFile(t, dir, "tools/go.mod", "module example.com/demo/tools\n")
+	writeFile(t, dir, "tools/tools.go", "package tools\n\nimport _ \"example.com/demo/internal/greet\"\n")
+
+	var warnings []string
+	project := Go.ProjectWith(dir, &Options{
+		GoMajorPath: true,
+		Warnf: func(format string, args ...any) {
+			warnings = append(warnings, fmt.Sprintf(format, args...))
+		},
+	})
+	newVersion, _ := version.Parse("2.0.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(dir, "go.mod"), "module example.com/demo/v2\n")
+	assertFileContains(t, filepath.Join(dir, "main.go"), `"example.com/demo/v2/internal/greet"`)
+	assertFileContains(t, filepath.Join(dir, "main.go"), `"example.com/demolition"`)
+	assertFileContains(t, filepath.Join(dir, "internal/greet/greet.go"), `import _ "example.com/demo/v2"`)
+	assertFileContains(t, filepath.Join(dir, "tools/tools.go"), `"example.com/demo/internal/greet"`)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "main.go:10") {
+		t.Fatalf("expected a warning for the comment of main.go, got %v", warnings)
+	}
+
+	newVersion, _ = version.Parse("3.0.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "go.mod"), "module example.com/demo/v3\n")
+	assertFileContains(t, filepath.Join(dir, "main.go"), `"example.com/demo/v3/internal/greet"`)
+	assertFileContains(t, filepath.Join(dir, "version.txt"), "3.0.0")
+}
+
+func TestGoProjectMajorPathWithVersionConstant(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "go.mod", "module example.com/demo\n\ngo 1.24\n")
+	writeFile(t, dir, "foo/foo.go", "package foo\n\nconst Name = \"foo\"\n")
+	writeFile(t, dir, "version.go", `package demo
+
+import "example.com/demo/foo"
+
+var _ = foo.Name
+
+const Version = "1.2.3"
+`)
+
+	project := Go.ProjectWith(dir, &Options{GoMajorPath: true})
+	newVersion, _ := version.Parse("2.0.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+
+	versionGo := filepath.Join(dir, "version.go")
+	assertFileContains(t, versionGo, `import "example.com/demo/v2/foo"`)
+	assertFileContains(t, versionGo, `const Version = "2.0.0"`+"\n")
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "2.0.0" {
+		t.Fatalf("expected version 2.0.0, got %s", v)
+	}
+}
+
+func TestGoProjectVersionConstant(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "go.mod", "module example.com/demo\n\ngo 1.24\n")
+	writeFile(t, dir, "internal/build/version.go", `package build
+
+// Version is the release of the binary
+const Version = "1.2.3" // set by verit
+
+var Commit string
+`)
+	writeFile(t, dir, "internal/build/version_test.go", "package build\n\nconst Version = \"0.0.0\"\n")
+	writeFile(t, dir, "main.go", "package main\n\nvar AppVersion = `0.9.0`\n")
+
+	project := Go.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.3" {
+		t.Fatalf("expected version 1.2.3, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "internal/build/version.go"), `const Version = "1.3.0" // set by verit`)
+
+	project = Go.ProjectWith(dir, &Options{GoVersionName: "AppVersion"})
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "main.go"), "var AppVersion = `1.3.0`")
+}
+
+func TestDenoProjectVersionOperations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "deno.jsonc", `{
+  // published to JSR
+  "name": "@demo/edge",
+  "version": "1.2.3",
+  "tasks": { "dev": "deno run --watch main.ts" }, /* local tasks */
+}
+`)
+	writeFile(t, dir, "jsr.json", `{"name": "@demo/edge", "version": "1.2.3", "exports": "./mod.ts"}`)
+
+	if got := Which(dir); got != Deno {
+		t.Fatalf("expected Deno, got %v", got)
+	}
+
+	project := Deno.Project(dir)
+	v, err := project.GetVersion()
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if v.String() != "1.2.3" {
+		t.Fatalf("expected version 1.2.3, got %s", v)
+	}
+
+	newVersion, _ := version.Parse("1.3.0")
+	if err := project.SetVersion(newVersion); err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "deno.jsonc"), `  // published to JSR
+  "name": "@demo/edge",
+  "version": "1.3.0",`)
+	assertFileContains(t, filepath.Join(dir, "jsr.json"), `"version": "1.3.0"`)
+
+	writeFile(t, dir, "jsr.json", `{"name": "@demo/edge", "version": "1.0.0"}`)
+	if _, err := project.GetVersion(); err == nil {
+		t.Fatalf("expected a version mismatch error")
+	}
+
+	mixed := t.TempDir()
+	writeFile(t, mixed, "deno.json", `{"version": "1.2.3"}`)
+	writeFile(t, mixed, "package.json", `{"version": "1.2.3"}`)
+	if got := Which(mixed); got != Mix {
+		t.Fatalf("expected Mix, got %v", got)
+	}
+}
+
+func TestSmallManifestProjects(t *testing.T) {
+	cases := []struct {
+		id      ProjectID
+		file    string
+		content string
+		want    string
+		keep    string
+	}{
+		{
+			id:   Zig,
+			file: "build.zig.zon",
+			content: `.{
+    .name = .demo,
+    .version = "1.2.3",
+    .minimum_zig_version = "0.14.0",
+    .dependencies = .{},
+}
+`,
+			want: `    .version = "1.3.0",`,
+			keep: `.minimum_zig_version = "0.14.0"`,
+		},
+		{
+			id:   Nim,
+			file: "demo.nimble",
+			content: `# Package
+
+version       = "1.2.3"
+author        = "demo"
+
+requires "nim >= 2.0.0"
+`,
+			want: `version       = "1.3.0"`,
+			keep: `requires "nim >= 2.0.0"`,
+		},
+		{
+			id:   Crystal,
+			file: "shard.yml",
+			content: `name: demo
+version: 1.2.3
+
+dependencies:
+  kemal:
+    github: kemalcr/kemal
+    version: ~> 1.2.3
+`,
+			want: "version: 1.3.0\n",
+			keep: "    version: ~> 1.2.3\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.id.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, dir, c.file, c.content)
+
+			if got := Which(dir); got != c.id {
+				t.Fatalf("expected %v, got %v", c.id, got)
+			}
+
+			project := c.id.Project(dir)
+			v, err := project.GetVersion()
+			if err != nil {
+				t.Fatalf("get version: %v", err)
+			}
+			if v.String() != "1.2.3" {
+				t.Fatalf("expected version 1.2.3, got %s", v)
+			}
+
+			newVersion, _ := version.Parse("1.3.0")
+			if err := project.SetVersion(newVersion); err != nil {
+				t.Fatalf("set version: %v", err)
+			}
+			assertFileContains(t, filepath.Join(dir, c.file), c.want)
+			assertFileContains(t, filepath.Join(dir, c.file), c.keep)
+		})
+	}
+}
+
 func writeFile(t *testing.T, dir, name, content string) {
 	t.Helper()
 	path := filepath.Join(dir, name)
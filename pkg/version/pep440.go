@@ -0,0 +1,153 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// see https://packaging.python.org/en/latest/specifications/version-specifiers/#appendix-parsing-version-strings-with-regular-expressions
+var pep440Re = regexp.MustCompile(`(?i)^v?(?:(?P<epoch>\d+)!)?(?P<release>\d+(?:\.\d+)*)(?:[-_.]?(?P<pre>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pren>\d+)?)?(?:-(?P<postimplicit>\d+)|[-_.]?(?P<postl>post|rev|r)[-_.]?(?P<post>\d+)?)?(?:[-_.]?(?P<dev>dev)[-_.]?(?P<devn>\d+)?)?(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+var pep440Local = regexp.MustCompile(`(?i)^[a-z0-9]+(?:[-_.][a-z0-9]+)*$`)
+
+// pep440Pre maps the PEP 440 pre-release spellings to the semver prerelease label
+var pep440Pre = map[string]string{
+	"a":       "alpha",
+	"alpha":   "alpha",
+	"b":       "beta",
+	"beta":    "beta",
+	"c":       "rc",
+	"rc":      "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+// pep440Label maps the semver prerelease label to the canonical PEP 440 one
+var pep440Label = map[string]string{
+	"alpha": "a",
+	"beta":  "b",
+	"rc":    "rc",
+}
+
+/*
+ParsePEP440 parses a Python PEP 440 version:
+  - the `a`, `b` and `rc` pre-releases become the `alpha.N`, `beta.N` and `rc.N` prerelease
+  - a `.devN` release becomes the `dev.N` prerelease, after the pre-release if any
+  - a `.postN` release becomes the `post.N` build, followed by the local version label if any
+
+`1` and `1.2` are `1.0.0` and `1.2.0`, a non zero epoch or more than 3 release numbers are not supported.
+*/
+func ParsePEP440(s string) (*Version, error) {
+	m := pep440Re.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil, fmt.Errorf("invalid PEP 440 version string: %s", s)
+	}
+	group := func(name string) string {
+		return m[pep440Re.SubexpIndex(name)]
+	}
+	if epoch := group("epoch"); epoch != "" && parseInt(epoch) != 0 {
+		return nil, fmt.Errorf("version epoch is not supported: %s", s)
+	}
+	release := strings.Split(group("release"), ".")
+	if len(release) > 3 {
+		return nil, fmt.Errorf("more than 3 release numbers are not supported: %s", s)
+	}
+	for len(release) < 3 {
+		release = append(release, "0")
+	}
+
+	v := &Version{
+		Major: parseInt(release[0]),
+		Minor: parseInt(release[1]),
+		Patch: parseInt(release[2]),
+	}
+
+	var pre []string
+	if label := group("pre"); label != "" {
+		pre = append(pre, pep440Pre[strings.ToLower(label)], strconv.Itoa(parseInt(group("pren"))))
+	}
+	if group("dev") != "" {
+		pre = append(pre, "dev", strconv.Itoa(parseInt(group("devn"))))
+	}
+	v.Prerelease = strings.Join(pre, ".")
+
+	var build []string
+	if group("postimplicit") != "" || group("postl") != "" {
+		build = append(build, "post", strconv.Itoa(parseInt(group("postimplicit")+group("post"))))
+	}
+	if local := group("local"); local != "" {
+		build = append(build, strings.ToLower(strings.NewReplacer("-", ".", "_", ".").Replace(local)))
+	}
+	v.Build = strings.Join(build, ".")
+	return v, nil
+}
+
+var pep440PreToken = regexp.MustCompile(`^(alpha|beta|rc|a|b|c|pre|preview|dev)(\d*)$`)
+
+/*
+PEP440 returns the canonical PEP 440 form of the version, the reverse of ParsePEP440:
+`1.2.3-rc.1` is `1.2.3rc1`, `1.2.3-beta.2.dev.1` is `1.2.3b2.dev1`, and `1.2.3+post.1.ubuntu` is `1.2.3.post1+ubuntu`.
+*/
+func (v *Version) PEP440() (string, error) {
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch))
+
+	pre, dev := "", ""
+	tokens := strings.Split(strings.ToLower(v.Prerelease), ".")
+	for i := 0; v.Prerelease != "" && i < len(tokens); i++ {
+		m := pep440PreToken.FindStringSubmatch(tokens[i])
+		if m == nil {
+			return "", fmt.Errorf("prerelease %s has no PEP 440 equivalent, use alpha, beta, rc or dev", v.Prerelease)
+		}
+		n := m[2]
+		if n == "" && i+1 < len(tokens) && isDigits(tokens[i+1]) {
+			i++
+			n = tokens[i]
+		}
+		n = strconv.Itoa(parseInt(n))
+		if m[1] == "dev" {
+			if dev != "" {
+				return "", fmt.Errorf("prerelease %s has more than one dev release", v.Prerelease)
+			}
+			dev = ".dev" + n
+			continue
+		}
+		if pre != "" || dev != "" {
+			return "", fmt.Errorf("prerelease %s has no PEP 440 equivalent, use at most one of alpha, beta or rc before dev", v.Prerelease)
+		}
+		pre = pep440Label[pep440Pre[m[1]]] + n
+	}
+	b.WriteString(pre)
+
+	local := v.Build
+	if rest, ok := strings.CutPrefix(local, "post."); ok {
+		n, tail, _ := strings.Cut(rest, ".")
+		if isDigits(n) {
+			b.WriteString(".post" + strconv.Itoa(parseInt(n)))
+			local = tail
+		}
+	}
+	b.WriteString(dev)
+
+	if local != "" {
+		if !pep440Local.MatchString(local) {
+			return "", fmt.Errorf("build %s is not a valid PEP 440 local version label", v.Build)
+		}
+		b.WriteString("+" + strings.ToLower(local))
+	}
+	return b.String(), nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -162,3 +162,55 @@ func TestVersionComparison(t *testing.T) {
 		})
 	}
 }
+
+func TestPEP440(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in        string
+		want      Version
+		canonical string
+	}{
+		{in: "1.2.3", want: Version{Major: 1, Minor: 2, Patch: 3}, canonical: "1.2.3"},
+		{in: "1.2", want: Version{Major: 1, Minor: 2}, canonical: "1.2.0"},
+		{in: "1.2.3rc1", want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, canonical: "1.2.3rc1"},
+		{in: "1.2.3-alpha.1", want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "alpha.1"}, canonical: "1.2.3a1"},
+		{in: "1.2.3b", want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "beta.0"}, canonical: "1.2.3b0"},
+		{in: "1.2.3.dev4", want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "dev.4"}, canonical: "1.2.3.dev4"},
+		{in: "1.2.3a2.dev1", want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "alpha.2.dev.1"}, canonical: "1.2.3a2.dev1"},
+		{in: "1.2.3.post1", want: Version{Major: 1, Minor: 2, Patch: 3, Build: "post.1"}, canonical: "1.2.3.post1"},
+		{in: "1.2.3-2", want: Version{Major: 1, Minor: 2, Patch: 3, Build: "post.2"}, canonical: "1.2.3.post2"},
+		{in: "1.2.3+Ubuntu-1", want: Version{Major: 1, Minor: 2, Patch: 3, Build: "ubuntu.1"}, canonical: "1.2.3+ubuntu.1"},
+		{in: "1.2.3rc1.post2.dev3+local", want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1.dev.3", Build: "post.2.local"}, canonical: "1.2.3rc1.post2.dev3+local"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParsePEP440(tt.in)
+			if err != nil {
+				t.Fatalf("ParsePEP440(%q) error = %v, want nil", tt.in, err)
+			}
+			if *got != tt.want {
+				t.Fatalf("ParsePEP440(%q) = %#v, want %#v", tt.in, *got, tt.want)
+			}
+			canonical, err := got.PEP440()
+			if err != nil {
+				t.Fatalf("PEP440() error = %v, want nil", err)
+			}
+			if canonical != tt.canonical {
+				t.Fatalf("PEP440() = %q, want %q", canonical, tt.canonical)
+			}
+		})
+	}
+
+	for _, in := range []string{"1!1.2.3", "1.2.3.4", "1.2.3-snapshot"} {
+		if _, err := ParsePEP440(in); err == nil {
+			t.Errorf("ParsePEP440(%q) error = nil, want error", in)
+		}
+	}
+	for _, v := range []Version{{Prerelease: "snapshot"}, {Prerelease: "alpha.1.beta.2"}, {Build: "sha~1"}} {
+		if s, err := v.PEP440(); err == nil {
+			t.Errorf("PEP440() of %#v = %q, want error", v, s)
+		}
+	}
+}